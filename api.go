@@ -0,0 +1,134 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotStubbed is matched by the error returned by a Mock method whose function is not set.
+var ErrNotStubbed = errors.New("mailinator: mock method not stubbed")
+
+//go:generate go run mock_gen.go
+
+// API is the set of Mailinator API calls made by a Client. Code depending on API rather than
+// on *Client can be tested with a Mock.
+type API interface {
+	// Messages
+	FetchInbox(options *FetchInboxOptions) (*Inbox, error)
+	FetchInboxWithContext(ctx context.Context, options *FetchInboxOptions) (*Inbox, error)
+	FetchInboxMessage(options *FetchInboxMessageOptions) (*Message, error)
+	FetchInboxMessageWithContext(ctx context.Context, options *FetchInboxMessageOptions) (*Message, error)
+	FetchMessage(options *FetchMessageOptions) (*Message, error)
+	FetchMessageWithContext(ctx context.Context, options *FetchMessageOptions) (*Message, error)
+	FetchSMSMessage(options *FetchSMSMessageOptions) (*SMSMessage, error)
+	FetchSMSMessageWithContext(ctx context.Context, options *FetchSMSMessageOptions) (*SMSMessage, error)
+	FetchInboxMessageAtachments(options *FetchInboxMessageAttachmentsOptions) (*Attachments, error)
+	FetchInboxMessageAtachmentsWithContext(ctx context.Context, options *FetchInboxMessageAttachmentsOptions) (*Attachments, error)
+	FetchMessageAtachments(options *FetchMessageAttachmentsOptions) (*Attachments, error)
+	FetchMessageAtachmentsWithContext(ctx context.Context, options *FetchMessageAttachmentsOptions) (*Attachments, error)
+	FetchInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageAttachment(options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageLinks(options *FetchMessageLinksOptions) (*MessageLinks, error)
+	FetchMessageLinksWithContext(ctx context.Context, options *FetchMessageLinksOptions) (*MessageLinks, error)
+	FetchMessageLinksFull(options *FetchMessageLinksFullOptions) (*MessageLinksFull, error)
+	FetchMessageLinksFullWithContext(ctx context.Context, options *FetchMessageLinksFullOptions) (*MessageLinksFull, error)
+	FetchInboxMessageLinks(options *FetchInboxMessageLinksOptions) (*MessageLinks, error)
+	FetchInboxMessageLinksWithContext(ctx context.Context, options *FetchInboxMessageLinksOptions) (*MessageLinks, error)
+	DeleteAllDomainMessages(options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error)
+	DeleteAllDomainMessagesWithContext(ctx context.Context, options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error)
+	DeleteAllInboxMessages(options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error)
+	DeleteAllInboxMessagesWithContext(ctx context.Context, options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error)
+	DeleteMessage(options *DeleteMessageOptions) (*DeletedMessages, error)
+	DeleteMessageWithContext(ctx context.Context, options *DeleteMessageOptions) (*DeletedMessages, error)
+	PostMessage(options *PostMessageOptions) (*PostedMessage, error)
+	PostMessageWithContext(ctx context.Context, options *PostMessageOptions) (*PostedMessage, error)
+	FetchMessageSmtpLog(options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchMessageSmtpLogWithContext(ctx context.Context, options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchInboxMessageSmtpLog(options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchInboxMessageSmtpLogWithContext(ctx context.Context, options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchMessageRaw(options *FetchMessageRawOptions) (*string, error)
+	FetchMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*string, error)
+	FetchInboxMessageRaw(options *FetchInboxMessageRawOptions) (*string, error)
+	FetchInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*string, error)
+	FetchLatestMessages(options *FetchLatestMessagesOptions) (*Inbox, error)
+	FetchLatestMessagesWithContext(ctx context.Context, options *FetchLatestMessagesOptions) (*Inbox, error)
+	FetchLatestInboxMessages(options *FetchLatestInboxMessagesOptions) (*Inbox, error)
+	FetchLatestInboxMessagesWithContext(ctx context.Context, options *FetchLatestInboxMessagesOptions) (*Inbox, error)
+
+	// Streamed downloads
+	StreamInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*Download, error)
+	StreamInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*Download, error)
+	StreamMessageAttachment(options *FetchMessageAttachmentOptions) (*Download, error)
+	StreamMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*Download, error)
+	StreamMessageRaw(options *FetchMessageRawOptions) (*Download, error)
+	StreamMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*Download, error)
+	StreamInboxMessageRaw(options *FetchInboxMessageRawOptions) (*Download, error)
+	StreamInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*Download, error)
+	DownloadMessageAttachment(options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadMessageRaw(options *FetchMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageRaw(options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error)
+
+	// Domains
+	GetDomains() (*DomainsList, error)
+	GetDomainsWithContext(ctx context.Context) (*DomainsList, error)
+	GetDomain(options *GetDomainOptions) (*Domain, error)
+	GetDomainWithContext(ctx context.Context, options *GetDomainOptions) (*Domain, error)
+	CreateDomain(options *CreateDomainOptions) (*ResponseStatus, error)
+	CreateDomainWithContext(ctx context.Context, options *CreateDomainOptions) (*ResponseStatus, error)
+	DeleteDomain(options *DeleteDomainOptions) (*ResponseStatus, error)
+	DeleteDomainWithContext(ctx context.Context, options *DeleteDomainOptions) (*ResponseStatus, error)
+
+	// Rules
+	CreateRule(options *CreateRuleOptions) (*Rule, error)
+	CreateRuleWithContext(ctx context.Context, options *CreateRuleOptions) (*Rule, error)
+	EnableRule(options *EnableRuleOptions) (*ResponseStatus, error)
+	EnableRuleWithContext(ctx context.Context, options *EnableRuleOptions) (*ResponseStatus, error)
+	DisableRule(options *DisableRuleOptions) (*ResponseStatus, error)
+	DisableRuleWithContext(ctx context.Context, options *DisableRuleOptions) (*ResponseStatus, error)
+	GetAllRules(options *GetAllRulesOptions) (*Rules, error)
+	GetAllRulesWithContext(ctx context.Context, options *GetAllRulesOptions) (*Rules, error)
+	GetRule(options *GetRuleOptions) (*Rule, error)
+	GetRuleWithContext(ctx context.Context, options *GetRuleOptions) (*Rule, error)
+	DeleteRule(options *DeleteRuleOptions) (*ResponseStatus, error)
+	DeleteRuleWithContext(ctx context.Context, options *DeleteRuleOptions) (*ResponseStatus, error)
+
+	// Stats and team
+	GetTeamStats() (*TeamStats, error)
+	GetTeamStatsWithContext(ctx context.Context) (*TeamStats, error)
+	GetTeam() (*TeamInfo, error)
+	GetTeamWithContext(ctx context.Context) (*TeamInfo, error)
+	GetTeamInfo() (*TeamInfoData, error)
+	GetTeamInfoWithContext(ctx context.Context) (*TeamInfoData, error)
+
+	// Authenticators
+	InstantTOTP2FACode(options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error)
+	InstantTOTP2FACodeWithContext(ctx context.Context, options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error)
+	GetAuthenticators() (*Authenticators, error)
+	GetAuthenticatorsWithContext(ctx context.Context) (*Authenticators, error)
+	GetAuthenticatorsById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticatorsByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticator() (*Authenticators, error)
+	GetAuthenticatorWithContext(ctx context.Context) (*Authenticators, error)
+	GetAuthenticatorById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticatorByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+
+	// Webhooks
+	PrivateWebhook(options *PrivateWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateWebhookWithContext(ctx context.Context, options *PrivateWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateInboxWebhook(options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateInboxWebhookWithContext(ctx context.Context, options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateCustomServiceWebhook(options *PrivateCustomServiceWebhookOptions) error
+	PrivateCustomServiceWebhookWithContext(ctx context.Context, options *PrivateCustomServiceWebhookOptions) error
+	PrivateCustomServiceInboxWebhook(options *PrivateCustomServiceInboxWebhookOptions) error
+	PrivateCustomServiceInboxWebhookWithContext(ctx context.Context, options *PrivateCustomServiceInboxWebhookOptions) error
+}
+
+var _ API = (*Client)(nil)
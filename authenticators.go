@@ -1,7 +1,7 @@
 package mailinator
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -39,13 +39,18 @@ type GetAuthenticatorsByIdOptions struct {
 
 // Instant TOTP 2FA code.
 func (c *Client) InstantTOTP2FACode(options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/totp/%s", c.baseURL, options.TotpSecretKey), nil)
+	return c.InstantTOTP2FACodeWithContext(context.Background(), options)
+}
+
+// InstantTOTP2FACodeWithContext is like InstantTOTP2FACode but carries the given context on the request.
+func (c *Client) InstantTOTP2FACodeWithContext(ctx context.Context, options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("totp", options.TotpSecretKey), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := InstantTOTP2FACode{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("InstantTOTP2FACode", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -54,13 +59,18 @@ func (c *Client) InstantTOTP2FACode(options *InstantTOTP2FACodeOptions) (*Instan
 
 // Fetches Authenticators
 func (c *Client) GetAuthenticators() (*Authenticators, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/authenticators", c.baseURL), nil)
+	return c.GetAuthenticatorsWithContext(context.Background())
+}
+
+// GetAuthenticatorsWithContext is like GetAuthenticators but carries the given context on the request.
+func (c *Client) GetAuthenticatorsWithContext(ctx context.Context) (*Authenticators, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("authenticators"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Authenticators{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetAuthenticators", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -69,13 +79,18 @@ func (c *Client) GetAuthenticators() (*Authenticators, error) {
 
 // Fetch the TOTP 2FA code from one of your saved Keys
 func (c *Client) GetAuthenticatorsById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/authenticators/%s", c.baseURL, options.Id), nil)
+	return c.GetAuthenticatorsByIdWithContext(context.Background(), options)
+}
+
+// GetAuthenticatorsByIdWithContext is like GetAuthenticatorsById but carries the given context on the request.
+func (c *Client) GetAuthenticatorsByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("authenticators", options.Id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Authenticator{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetAuthenticatorsById", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -84,13 +99,18 @@ func (c *Client) GetAuthenticatorsById(options *GetAuthenticatorsByIdOptions) (*
 
 // Fetches Authenticator
 func (c *Client) GetAuthenticator() (*Authenticators, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/authenticator", c.baseURL), nil)
+	return c.GetAuthenticatorWithContext(context.Background())
+}
+
+// GetAuthenticatorWithContext is like GetAuthenticator but carries the given context on the request.
+func (c *Client) GetAuthenticatorWithContext(ctx context.Context) (*Authenticators, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("authenticator"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Authenticators{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetAuthenticator", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -99,13 +119,18 @@ func (c *Client) GetAuthenticator() (*Authenticators, error) {
 
 // Fetches Authenticator By Id
 func (c *Client) GetAuthenticatorById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/authenticator/%s", c.baseURL, options.Id), nil)
+	return c.GetAuthenticatorByIdWithContext(context.Background(), options)
+}
+
+// GetAuthenticatorByIdWithContext is like GetAuthenticatorById but carries the given context on the request.
+func (c *Client) GetAuthenticatorByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("authenticator", options.Id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Authenticator{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetAuthenticatorById", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,252 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by a *CircuitOpenError.
+var ErrCircuitOpen = errors.New("mailinator: circuit breaker is open")
+
+// CircuitOpenError is returned without sending the request while the CircuitBreaker is open.
+type CircuitOpenError struct {
+	// RetryAfter is how long until the breaker lets a probe request through.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return ErrCircuitOpen.Error()
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all requests through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all requests until the cooldown has elapsed.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of probe requests through.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// BreakerSettings configures a CircuitBreaker.
+type BreakerSettings struct {
+	// FailureRatio is the ratio of failed requests within Window that opens the breaker.
+	FailureRatio float64
+	// MinRequests is the number of requests within Window needed before the ratio is considered.
+	MinRequests int
+	// Window is the period over which requests are counted while closed.
+	Window time.Duration
+	// Cooldown is how long the breaker stays open before letting probes through.
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of concurrent probes allowed while half-open.
+	HalfOpenRequests int
+	// OnStateChange, if set, is called on every state change. It must not block or call the breaker.
+	OnStateChange func(from, to BreakerState)
+}
+
+// CircuitBreaker stops sending requests while the API is failing.
+// Transport errors and 5xx responses count as failures. A single CircuitBreaker
+// may be shared by several clients and goroutines.
+type CircuitBreaker struct {
+	settings BreakerSettings
+
+	mu          sync.Mutex
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	// generation counts state changes, so that results of requests let through
+	// in an earlier state are not taken for those of the current one.
+	generation uint64
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker. Zero settings get defaults:
+// a 50% failure ratio over at least 10 requests in a 1 minute window, and a 30 second cooldown with 1 probe.
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	if settings.FailureRatio <= 0 {
+		settings.FailureRatio = 0.5
+	}
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = 10
+	}
+	if settings.Window <= 0 {
+		settings.Window = time.Minute
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = 30 * time.Second
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+
+	return &CircuitBreaker{
+		settings:    settings,
+		windowStart: time.Now(),
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(time.Now())
+	return b.state
+}
+
+// allow reports whether a request may be sent now, and returns the generation it was let through in.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refresh(now)
+
+	switch b.state {
+	case BreakerOpen:
+		return 0, &CircuitOpenError{RetryAfter: b.openedAt.Add(b.settings.Cooldown).Sub(now)}
+	case BreakerHalfOpen:
+		if b.probes >= b.settings.HalfOpenRequests {
+			return 0, &CircuitOpenError{}
+		}
+		b.probes++
+	}
+
+	return b.generation, nil
+}
+
+// record accounts for the outcome of a request let through by allow in generation.
+// Outcomes of requests let through before the last state change are ignored: a request
+// sent while closed says nothing about the probes of a half-open breaker.
+func (b *CircuitBreaker) record(ctx context.Context, generation uint64, res *http.Response, err error) {
+	failed := (err != nil && ctx.Err() == nil) || (res != nil && res.StatusCode >= http.StatusInternalServerError)
+	if err != nil && !failed {
+		// The caller gave up; this says nothing about the API.
+		b.mu.Lock()
+		if b.state == BreakerHalfOpen && b.generation == generation && b.probes > 0 {
+			b.probes--
+		}
+		b.mu.Unlock()
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refresh(now)
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case BreakerHalfOpen:
+		if failed {
+			b.open(now)
+		} else {
+			b.setState(BreakerClosed)
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	case BreakerClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.settings.MinRequests && float64(b.failures)/float64(b.requests) >= b.settings.FailureRatio {
+			b.open(now)
+		}
+	}
+}
+
+// refresh moves the breaker from open to half-open once the cooldown has elapsed,
+// and starts a new counting window when the current one is over.
+func (b *CircuitBreaker) refresh(now time.Time) {
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) >= b.settings.Cooldown {
+			b.probes = 0
+			b.setState(BreakerHalfOpen)
+		}
+	case BreakerClosed:
+		if now.Sub(b.windowStart) >= b.settings.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	}
+}
+
+func (b *CircuitBreaker) open(now time.Time) {
+	b.openedAt = now
+	b.setState(BreakerOpen)
+}
+
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	b.generation++
+
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, state)
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast with a *CircuitOpenError while breaker is open.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.breaker = breaker
+	}
+}
+
+// admit waits for the client's RateLimiter and checks its CircuitBreaker before each attempt.
+// It returns the breaker generation the attempt was let through in.
+func (c *Client) admit(req *http.Request) (uint64, error) {
+	if err := c.wait(req); err != nil {
+		return 0, err
+	}
+
+	if c.breaker != nil {
+		return c.breaker.allow()
+	}
+
+	return 0, nil
+}
+
+// attempt sends a single attempt of req that was admitted in generation, reporting its outcome to the CircuitBreaker.
+func (c *Client) attempt(req *http.Request, generation uint64) (*http.Response, error) {
+	res, err := c.hedge(req)
+
+	if c.breaker != nil {
+		c.breaker.record(req.Context(), generation, res, err)
+	}
+
+	return res, err
+}
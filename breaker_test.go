@@ -0,0 +1,108 @@
+package mailinator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensAndHalfOpens(t *testing.T) {
+	var requests int32
+	var failing int32 = 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domains":[]}`))
+	}))
+	defer server.Close()
+
+	var transitions []BreakerState
+	breaker := NewCircuitBreaker(BreakerSettings{
+		FailureRatio: 0.5,
+		MinRequests:  2,
+		Cooldown:     100 * time.Millisecond,
+		OnStateChange: func(from, to BreakerState) {
+			transitions = append(transitions, to)
+		},
+	})
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(nil), WithCircuitBreaker(breaker))
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetDomains()
+		assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+	}
+	assert.Equal(t, BreakerOpen, breaker.State(), "expecting open breaker after failures")
+
+	_, err := c.GetDomains()
+	assert.True(t, errors.Is(err, ErrCircuitOpen), "expecting call rejected while open")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "expecting no request sent while open")
+
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, BreakerHalfOpen, breaker.State(), "expecting half-open breaker after the cooldown")
+
+	atomic.StoreInt32(&failing, 0)
+	_, err = c.GetDomains()
+	assert.Nil(t, err, "expecting probe to succeed")
+	assert.Equal(t, BreakerClosed, breaker.State(), "expecting closed breaker after a successful probe")
+	assert.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerClosed}, transitions, "expecting every transition reported")
+}
+
+func TestCircuitBreakerIgnoresStaleResultsWhenHalfOpen(t *testing.T) {
+	var requests int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			close(started)
+			<-release
+		case 2, 3:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domains":[]}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(BreakerSettings{
+		FailureRatio: 0.5,
+		MinRequests:  2,
+		Cooldown:     50 * time.Millisecond,
+	})
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(nil), WithCircuitBreaker(breaker))
+
+	// A slow request is let through while the breaker is closed.
+	slow := make(chan error, 1)
+	go func() {
+		_, err := c.GetDomains()
+		slow <- err
+	}()
+	<-started
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetDomains()
+		assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+	}
+	assert.Equal(t, BreakerOpen, breaker.State(), "expecting open breaker after failures")
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, BreakerHalfOpen, breaker.State(), "expecting half-open breaker after the cooldown")
+
+	close(release)
+	assert.Nil(t, <-slow, "expecting slow request to succeed")
+	assert.Equal(t, BreakerHalfOpen, breaker.State(), "expecting a request let through while closed not to close the breaker")
+
+	_, err := c.GetDomains()
+	assert.Nil(t, err, "expecting probe to succeed")
+	assert.Equal(t, BreakerClosed, breaker.State(), "expecting closed breaker after a successful probe")
+}
@@ -0,0 +1,247 @@
+package mailinator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheSettings configures a ResponseCache.
+type CacheSettings struct {
+	// TTL is how long responses of GetDomains, GetDomain, GetAllRules, GetTeam, GetTeamInfo
+	// and GetAuthenticators are reused. Zero means 1 minute.
+	TTL time.Duration
+	// EndpointTTL overrides TTL by endpoint name, e.g. "GetTeamInfo". It may also add other
+	// GET endpoints. A negative duration disables caching for the endpoint.
+	EndpointTTL map[string]time.Duration
+}
+
+// cachedEndpoints are the endpoints cached by default, whose data rarely changes.
+var cachedEndpoints = []string{
+	"GetDomains",
+	"GetDomain",
+	"GetAllRules",
+	"GetTeam",
+	"GetTeamInfo",
+	"GetAuthenticators",
+}
+
+// ResponseCache keeps successful GET responses of slow-changing endpoints.
+// Once an entry expires, it is revalidated with a conditional request if the server sent
+// an ETag or Last-Modified header. Any other request sent through the client, such as
+// CreateRule or DeleteDomain, drops the entries of its API token, both before it is sent and
+// after it completes, and GET responses received meanwhile are not kept.
+// A ResponseCache is safe for concurrent use.
+type ResponseCache struct {
+	ttl map[string]time.Duration
+
+	mu sync.Mutex
+	// entries holds the entries of each token, by URL. Tokens are keyed by their hash.
+	entries map[string]map[string]*cacheEntry
+	// generations counts the invalidations of each token, so that responses to requests sent
+	// before an invalidation are not stored after it.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	body         []byte
+	header       http.Header
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+// NewResponseCache returns an empty ResponseCache.
+func NewResponseCache(settings CacheSettings) *ResponseCache {
+	if settings.TTL <= 0 {
+		settings.TTL = time.Minute
+	}
+
+	ttl := map[string]time.Duration{}
+	for _, endpoint := range cachedEndpoints {
+		ttl[endpoint] = settings.TTL
+	}
+	for endpoint, d := range settings.EndpointTTL {
+		ttl[endpoint] = d
+	}
+
+	return &ResponseCache{
+		ttl:         ttl,
+		entries:     map[string]map[string]*cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// Invalidate empties the cache.
+func (rc *ResponseCache) Invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = map[string]map[string]*cacheEntry{}
+	for token := range rc.generations {
+		rc.generations[token]++
+	}
+}
+
+// invalidate drops the entries of token.
+func (rc *ResponseCache) invalidate(token string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, token)
+	rc.generations[token]++
+}
+
+// lookup returns the entry of url for token, if any, and the generation to store its response with.
+func (rc *ResponseCache) lookup(token string, url string) (*cacheEntry, uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	generation, ok := rc.generations[token]
+	if !ok {
+		rc.generations[token] = 0
+	}
+
+	return rc.entries[token][url], generation
+}
+
+// store keeps entry for url and token, unless the entries of token were dropped since generation.
+func (rc *ResponseCache) store(token string, url string, generation uint64, entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.generations[token] != generation {
+		return
+	}
+
+	if rc.entries[token] == nil {
+		rc.entries[token] = map[string]*cacheEntry{}
+	}
+	rc.entries[token][url] = entry
+}
+
+// WithResponseCache makes the client reuse responses from cache. A cache may be shared by
+// several clients; entries are kept apart by API token.
+func WithResponseCache(cache *ResponseCache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// read sends req and reads the response body, going through the client's ResponseCache if any.
+func (c *Client) read(endpoint string, req *http.Request) (*http.Response, []byte, int, error) {
+	if c.cache == nil {
+		return c.fetch(endpoint, req)
+	}
+
+	ttl := c.cache.ttl[endpoint]
+	if req.Method == http.MethodGet && ttl <= 0 {
+		return c.fetch(endpoint, req)
+	}
+
+	token, err := c.token(req.Context())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	tokenKey := cacheTokenKey(token)
+
+	if req.Method != http.MethodGet {
+		c.cache.invalidate(tokenKey)
+		defer c.cache.invalidate(tokenKey)
+		return c.fetch(endpoint, req)
+	}
+
+	url := req.URL.String()
+	entry, generation := c.cache.lookup(tokenKey, url)
+
+	if entry != nil {
+		if time.Now().Before(entry.expires) {
+			return &http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header:     entry.header.Clone(),
+				Body:       cachedBody{},
+				Request:    req,
+			}, entry.body, 0, nil
+		}
+
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	res, body, retries, err := c.fetch(endpoint, req)
+	if err != nil {
+		return res, body, retries, err
+	}
+
+	if res.StatusCode == http.StatusNotModified && entry != nil {
+		// A 304 only carries the headers that changed.
+		header := entry.header.Clone()
+		for name, values := range res.Header {
+			header[name] = values
+		}
+		res.Header = header
+
+		c.cache.store(tokenKey, url, generation, &cacheEntry{
+			body:         entry.body,
+			header:       header.Clone(),
+			etag:         entry.etag,
+			lastModified: entry.lastModified,
+			expires:      time.Now().Add(ttl),
+		})
+		return res, entry.body, retries, nil
+	}
+
+	c.cache.store(tokenKey, url, generation, &cacheEntry{
+		body:         body,
+		header:       res.Header.Clone(),
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+		expires:      time.Now().Add(ttl),
+	})
+
+	return res, body, retries, nil
+}
+
+// readResponse sends req and reads the response body.
+func (c *Client) readResponse(endpoint string, req *http.Request) (*http.Response, []byte, int, error) {
+	res, retries, err := c.roundTrip(endpoint, req)
+	if err != nil {
+		return res, nil, retries, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	return res, body, retries, err
+}
+
+// cacheTokenKey identifies the entries of token, without keeping the token itself.
+func cacheTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedBody is the body of responses served from cache, which observe tells apart from sent requests.
+type cachedBody struct{}
+
+func (cachedBody) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (cachedBody) Close() error {
+	return nil
+}
+
+// conditional reports whether req revalidates a cached response.
+func conditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
@@ -0,0 +1,128 @@
+package mailinator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDomainsServer answers GetDomains, counting the GETs, and answers any other request with a status.
+func newDomainsServer(gets *int32, handler func()) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			w.Write([]byte(`{"status":"ok"}`))
+			return
+		}
+
+		atomic.AddInt32(gets, 1)
+		if handler != nil {
+			handler()
+		}
+		w.Write([]byte(`{"domains":[]}`))
+	}))
+}
+
+func TestResponseCacheReusesResponses(t *testing.T) {
+	var gets int32
+	server := newDomainsServer(&gets, nil)
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c := NewClient("token", WithBaseURL(server.URL), WithResponseCache(NewResponseCache(CacheSettings{})), WithMetrics(metrics))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.GetDomains()
+		assert.Nil(t, err, "expecting nil error")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting a single request")
+
+	var out bytes.Buffer
+	metrics.WritePrometheus(&out)
+	assert.Contains(t, out.String(), `mailinator_requests_total{endpoint="GetDomains",status="200"} 1`, "expecting one request counted")
+	assert.Contains(t, out.String(), `mailinator_cache_hits_total{endpoint="GetDomains"} 2`, "expecting cache hits counted apart")
+
+	_, err := c.DeleteDomain(&DeleteDomainOptions{DomainId: "d"})
+	assert.Nil(t, err, "expecting nil error")
+
+	_, err = c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets), "expecting a new request after a mutation")
+}
+
+func TestResponseCacheDropsResponsesReceivedDuringMutation(t *testing.T) {
+	var gets int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	server := newDomainsServer(&gets, func() {
+		if atomic.LoadInt32(&gets) == 1 {
+			close(arrived)
+			<-release
+		}
+	})
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithResponseCache(NewResponseCache(CacheSettings{})))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.GetDomains()
+	}()
+
+	<-arrived
+	_, err := c.DeleteDomain(&DeleteDomainOptions{DomainId: "d"})
+	assert.Nil(t, err, "expecting nil error")
+	close(release)
+	wg.Wait()
+
+	_, err = c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets), "expecting the response received during the mutation not to be cached")
+}
+
+func TestResponseCacheKeepsOtherTokens(t *testing.T) {
+	var gets int32
+	server := newDomainsServer(&gets, nil)
+	defer server.Close()
+
+	cache := NewResponseCache(CacheSettings{})
+	reader := NewClient("reader", WithBaseURL(server.URL), WithResponseCache(cache))
+	writer := NewClient("writer", WithBaseURL(server.URL), WithResponseCache(cache))
+
+	_, err := reader.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+
+	_, err = writer.DeleteDomain(&DeleteDomainOptions{DomainId: "d"})
+	assert.Nil(t, err, "expecting nil error")
+
+	_, err = reader.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting the entries of other tokens kept")
+}
+
+func TestResponseCacheKeepsHeadersOfCallers(t *testing.T) {
+	var gets int32
+	server := newDomainsServer(&gets, nil)
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithResponseCache(NewResponseCache(CacheSettings{})))
+
+	var first Response
+	_, err := c.GetDomainsWithContext(CaptureResponse(context.Background(), &first))
+	assert.Nil(t, err, "expecting nil error")
+	first.Header.Set("Content-Type", "text/plain")
+
+	var second Response
+	_, err = c.GetDomainsWithContext(CaptureResponse(context.Background(), &second))
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting a cached response")
+	assert.Equal(t, "application/json", second.Header.Get("Content-Type"), "expecting cached headers unchanged by callers")
+}
@@ -0,0 +1,370 @@
+package mailinator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Interaction is a recorded request/response pair. A cassette is a JSONL file
+// holding one Interaction per line.
+type Interaction struct {
+	Endpoint string              `json:"endpoint"`
+	Request  InteractionRequest  `json:"request"`
+	Response InteractionResponse `json:"response"`
+}
+
+// InteractionRequest is the request half of an Interaction.
+type InteractionRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// InteractionResponse is the response half of an Interaction.
+type InteractionResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// encodeBody stores text bodies as is and binary bodies, such as attachments, as base64.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeBody(text, encoded string) ([]byte, error) {
+	if encoded != "" {
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+
+	return []byte(text), nil
+}
+
+// redactJSONBody replaces the value of every "token" field of a JSON body, such as TeamInfo.Token.
+func redactJSONBody(body []byte) []byte {
+	if !bytes.Contains(body, []byte(`"token"`)) {
+		return body
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+
+	redactJSONValue(doc)
+
+	clean, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+
+	return clean
+}
+
+func redactJSONValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if key == "token" {
+				if _, ok := field.(string); ok {
+					v[key] = redacted
+					continue
+				}
+			}
+			redactJSONValue(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactJSONValue(item)
+		}
+	}
+}
+
+// Recorder appends every request/response pair passing through it to a JSONL cassette.
+// Credentials are redacted before anything is written.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder returns a Recorder writing to w, typically a file opened for appending.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Middleware records the requests of a client; pass it to WithMiddleware.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var reqBody []byte
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			reqBody, err = ioutil.ReadAll(body)
+			body.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		resBody, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+		interaction := Interaction{
+			Endpoint: EndpointFromContext(req.Context()),
+			Request: InteractionRequest{
+				Method:  req.Method,
+				URL:     redactURL(req.URL),
+				Headers: redactHeader(req.Header),
+			},
+			Response: InteractionResponse{
+				StatusCode: res.StatusCode,
+				Headers:    redactHeader(res.Header),
+			},
+		}
+		interaction.Request.Body, interaction.Request.BodyBase64 = encodeBody(reqBody)
+		interaction.Response.Body, interaction.Response.BodyBase64 = encodeBody(redactJSONBody(resBody))
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if err := r.enc.Encode(interaction); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	})
+}
+
+// LoadCassette reads the interactions of a JSONL cassette.
+func LoadCassette(r io.Reader) ([]Interaction, error) {
+	var interactions []Interaction
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %v", line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return interactions, nil
+}
+
+// MatchOptions controls how a Replayer matches live requests against recorded ones.
+// Method and path must always be equal.
+type MatchOptions struct {
+	// IgnoreQuery matches on method and path only.
+	IgnoreQuery bool
+	// IgnoreQueryOrder compares query parameters regardless of their order.
+	IgnoreQueryOrder bool
+	// IgnoreParams lists query parameters left out of the comparison, e.g. "cursor".
+	IgnoreParams []string
+	// Repeat allows an interaction to be replayed more than once.
+	Repeat bool
+}
+
+// UnmatchedRequestError is returned by a Replayer for a request that matches no recorded interaction.
+type UnmatchedRequestError struct {
+	Method string
+	URL    string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("mailinator: no recorded interaction matches %s %s", e.Method, e.URL)
+}
+
+// Replayer is an http.RoundTripper serving recorded responses instead of calling the API.
+// Interactions are replayed in recorded order; each one is used once unless MatchOptions.Repeat is set.
+type Replayer struct {
+	match        MatchOptions
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer serving interactions; pass it to WithTransport.
+func NewReplayer(interactions []Interaction, match MatchOptions) *Replayer {
+	return &Replayer{
+		match:        match,
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// RoundTrip returns the response of the first unused interaction matching req.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	live := redactURL(req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] && !r.match.Repeat {
+			continue
+		}
+
+		if !r.matches(req.Method, live, interaction.Request) {
+			continue
+		}
+
+		body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyBase64)
+		if err != nil {
+			return nil, err
+		}
+
+		r.used[i] = true
+
+		header := interaction.Response.Headers.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &UnmatchedRequestError{Method: req.Method, URL: live}
+}
+
+// Unused returns the interactions that were never replayed.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+func (r *Replayer) matches(method, live string, recorded InteractionRequest) bool {
+	if method != recorded.Method {
+		return false
+	}
+
+	liveURL, err := url.Parse(live)
+	if err != nil {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	if liveURL.EscapedPath() != recordedURL.EscapedPath() {
+		return false
+	}
+
+	if r.match.IgnoreQuery {
+		return true
+	}
+
+	liveQuery, recordedQuery := liveURL.Query(), recordedURL.Query()
+	for _, param := range r.match.IgnoreParams {
+		liveQuery.Del(param)
+		recordedQuery.Del(param)
+	}
+
+	if r.match.IgnoreQueryOrder {
+		return canonicalQuery(liveQuery) == canonicalQuery(recordedQuery)
+	}
+
+	if len(r.match.IgnoreParams) > 0 {
+		return stripParams(liveURL.RawQuery, r.match.IgnoreParams) == stripParams(recordedURL.RawQuery, r.match.IgnoreParams)
+	}
+
+	return liveURL.RawQuery == recordedURL.RawQuery
+}
+
+// canonicalQuery encodes query with keys and values sorted.
+func canonicalQuery(query url.Values) string {
+	for _, values := range query {
+		sort.Strings(values)
+	}
+
+	return query.Encode()
+}
+
+// stripParams removes params from a raw query while keeping the order of the others.
+func stripParams(rawQuery string, params []string) string {
+	var kept []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		key := pair
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			key = pair[:i]
+		}
+		if name, err := url.QueryUnescape(key); pair == "" || (err == nil && contains(params, name)) {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	return strings.Join(kept, "&")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
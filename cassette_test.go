@@ -0,0 +1,110 @@
+package mailinator
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inboxInteraction records a FetchInbox of d/i with the given raw query.
+func inboxInteraction(rawQuery string) Interaction {
+	return Interaction{
+		Endpoint: "FetchInbox",
+		Request: InteractionRequest{
+			Method: "GET",
+			URL:    defaultBaseURL + "/domains/d/inboxes/i?" + rawQuery,
+		},
+		Response: InteractionResponse{
+			StatusCode: http.StatusOK,
+			Headers:    http.Header{"Content-Type": []string{"application/json"}},
+			Body:       `{"msgs":[]}`,
+		},
+	}
+}
+
+// The raw query FetchInbox sends for d/i sorted in ascending order.
+const inboxQuery = "skip=0&limit=50&sort=ascending&decode_subject=false"
+
+func replayClient(match MatchOptions, interactions ...Interaction) *Client {
+	return NewClient("token", WithTransport(NewReplayer(interactions, match)))
+}
+
+func TestRecorderRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		w.Write([]byte(`{"token":"body-secret"}`))
+	}))
+	defer server.Close()
+
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette)
+	c := NewClient("header-secret", WithBaseURL(server.URL), WithMiddleware(recorder.Middleware))
+
+	_, err := c.GetTeamInfo()
+	assert.Nil(t, err, "expecting nil error")
+
+	for _, secret := range []string{"header-secret", "cookie-secret", "body-secret"} {
+		assert.NotContains(t, cassette.String(), secret, "expecting redacted cassette")
+	}
+
+	interactions, err := LoadCassette(&cassette)
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, 1, len(interactions), "expecting one interaction")
+}
+
+func TestReplayerIgnoreQueryOrder(t *testing.T) {
+	reordered := inboxInteraction("decode_subject=false&sort=ascending&limit=50&skip=0")
+
+	_, err := replayClient(MatchOptions{}, reordered).FetchInbox(&FetchInboxOptions{Domain: "d", Inbox: "i", Sort: "ascending"})
+	var unmatched *UnmatchedRequestError
+	assert.True(t, errors.As(err, &unmatched), "expecting unmatched request without IgnoreQueryOrder")
+
+	_, err = replayClient(MatchOptions{IgnoreQueryOrder: true}, reordered).FetchInbox(&FetchInboxOptions{Domain: "d", Inbox: "i", Sort: "ascending"})
+	assert.Nil(t, err, "expecting match with IgnoreQueryOrder")
+}
+
+func TestReplayerIgnoreParams(t *testing.T) {
+	recorded := inboxInteraction(inboxQuery + "&cursor=recorded")
+	options := &FetchInboxOptions{Domain: "d", Inbox: "i", Sort: "ascending", Cursor: "live"}
+
+	_, err := replayClient(MatchOptions{}, recorded).FetchInbox(options)
+	var unmatched *UnmatchedRequestError
+	assert.True(t, errors.As(err, &unmatched), "expecting unmatched request without IgnoreParams")
+
+	_, err = replayClient(MatchOptions{IgnoreParams: []string{"cursor"}}, recorded).FetchInbox(options)
+	assert.Nil(t, err, "expecting match ignoring cursor")
+}
+
+func TestReplayerRepeat(t *testing.T) {
+	options := &FetchInboxOptions{Domain: "d", Inbox: "i", Sort: "ascending"}
+
+	once := replayClient(MatchOptions{}, inboxInteraction(inboxQuery))
+	_, err := once.FetchInbox(options)
+	assert.Nil(t, err, "expecting first replay")
+	_, err = once.FetchInbox(options)
+	var unmatched *UnmatchedRequestError
+	assert.True(t, errors.As(err, &unmatched), "expecting interaction used once without Repeat")
+
+	repeated := replayClient(MatchOptions{Repeat: true}, inboxInteraction(inboxQuery))
+	for i := 0; i < 3; i++ {
+		_, err = repeated.FetchInbox(options)
+		assert.Nil(t, err, "expecting repeated replay")
+	}
+}
+
+func TestReplayerUnmatchedRequestError(t *testing.T) {
+	c := replayClient(MatchOptions{}, inboxInteraction(inboxQuery))
+
+	_, err := c.GetDomains()
+	var unmatched *UnmatchedRequestError
+	assert.True(t, errors.As(err, &unmatched), "expecting *UnmatchedRequestError")
+	if unmatched != nil {
+		assert.Equal(t, "GET", unmatched.Method, "expecting method of the request")
+		assert.Equal(t, defaultBaseURL+"/domains", unmatched.URL, "expecting URL of the request")
+	}
+}
@@ -0,0 +1,102 @@
+package mailinator
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithRequestCoalescing makes the client merge identical GET requests in flight at the same
+// time, for the same URL and API token, into a single HTTP call whose response is shared.
+// If the call fails because the caller that made it gave up, the other callers send it again.
+func WithRequestCoalescing() Option {
+	return func(c *Client) {
+		c.flights = &flightGroup{calls: map[string]*flight{}}
+	}
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	req     *http.Request
+	res     *http.Response
+	body    []byte
+	retries int
+	err     error
+}
+
+// fetch reads the response to req, sharing it with identical requests already in flight.
+func (c *Client) fetch(endpoint string, req *http.Request) (*http.Response, []byte, int, error) {
+	if c.flights == nil || req.Method != http.MethodGet {
+		return c.readResponse(endpoint, req)
+	}
+
+	token, err := c.token(req.Context())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	key := cacheTokenKey(token) + " " + req.URL.String() + "\n" + req.Header.Get("If-None-Match") + "\n" + req.Header.Get("If-Modified-Since")
+
+	c.flights.mu.Lock()
+	if f, ok := c.flights.calls[key]; ok {
+		c.flights.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-req.Context().Done():
+			return nil, nil, 0, req.Context().Err()
+		}
+
+		if f.err != nil && f.req.Context().Err() != nil && req.Context().Err() == nil {
+			return c.fetch(endpoint, req)
+		}
+
+		res := shared(f.res)
+		if res != nil {
+			// The bytes were downloaded, and counted, by the caller that made the request.
+			res.Body = sharedBody{}
+		}
+
+		return res, f.body, f.retries, f.err
+	}
+
+	f := &flight{done: make(chan struct{}), req: req}
+	c.flights.calls[key] = f
+	c.flights.mu.Unlock()
+
+	f.res, f.body, f.retries, f.err = c.readResponse(endpoint, req)
+
+	c.flights.mu.Lock()
+	delete(c.flights.calls, key)
+	c.flights.mu.Unlock()
+	close(f.done)
+
+	return shared(f.res), f.body, f.retries, f.err
+}
+
+// shared returns a copy of res for one of the callers sharing it.
+func shared(res *http.Response) *http.Response {
+	if res == nil {
+		return nil
+	}
+
+	copied := *res
+	return &copied
+}
+
+// sharedBody is the body of responses shared by a caller that did not make the request,
+// which observe does not count as downloaded.
+type sharedBody struct{}
+
+func (sharedBody) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (sharedBody) Close() error {
+	return nil
+}
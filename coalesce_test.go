@@ -0,0 +1,61 @@
+package mailinator
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCoalescingSharesResponse(t *testing.T) {
+	var gets int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	server := newDomainsServer(&gets, func() {
+		if atomic.LoadInt32(&gets) == 1 {
+			close(arrived)
+			<-release
+		}
+	})
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c := NewClient("token", WithBaseURL(server.URL), WithRequestCoalescing(), WithMetrics(metrics))
+
+	const callers = 3
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	call := func() {
+		defer wg.Done()
+		_, err := c.GetDomains()
+		errs <- err
+	}
+
+	wg.Add(1)
+	go call()
+	<-arrived
+
+	wg.Add(callers - 1)
+	for i := 1; i < callers; i++ {
+		go call()
+	}
+	// Let the other callers join the request in flight.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.Nil(t, err, "expecting nil error")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting a single request")
+
+	var out bytes.Buffer
+	metrics.WritePrometheus(&out)
+	assert.Contains(t, out.String(), fmt.Sprintf(`mailinator_requests_total{endpoint="GetDomains",status="200"} %d`, callers), "expecting every call counted")
+	assert.Contains(t, out.String(), fmt.Sprintf(`mailinator_response_bytes_total{endpoint="GetDomains"} %d`, len(`{"domains":[]}`)), "expecting the bytes counted once")
+}
@@ -0,0 +1,233 @@
+package mailinator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the API token sent with every request.
+// Token is called once per request, so implementations should be cheap and safe for concurrent use.
+type CredentialProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// CredentialRefresher is implemented by providers that cache their token.
+// When the API rejects a token with 401, the client calls Refresh once and, if the token
+// changed, sends the request again. Providers without Refresh are asked for a Token again instead.
+type CredentialRefresher interface {
+	Refresh(ctx context.Context) (string, error)
+}
+
+// StaticCredentials returns a provider always supplying token.
+func StaticCredentials(token string) CredentialProvider {
+	return staticCredentials(token)
+}
+
+type staticCredentials string
+
+func (s staticCredentials) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvCredentials returns a provider reading the token from the environment variable name on every request.
+func EnvCredentials(name string) CredentialProvider {
+	return envCredentials(name)
+}
+
+type envCredentials string
+
+func (e envCredentials) Token(ctx context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(e)))
+	if token == "" {
+		return "", fmt.Errorf("mailinator: environment variable %s is not set", string(e))
+	}
+
+	return token, nil
+}
+
+// FileCredentials is a provider reading the token from a file, re-read whenever the file changes.
+type FileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials returns a provider reading the token from the file at path.
+// Surrounding whitespace is ignored.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+// Token returns the token from the file, reading it again if it was modified since the last read.
+func (f *FileCredentials) Token(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	return f.read(info)
+}
+
+// Refresh reads the file again.
+func (f *FileCredentials) Refresh(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.read(info)
+}
+
+func (f *FileCredentials) read(info os.FileInfo) (string, error) {
+	content, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("mailinator: credentials file %s is empty", f.path)
+	}
+
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// CommandCredentials is a provider running an external command, such as a secrets manager CLI,
+// and using its standard output as the token.
+type CommandCredentials struct {
+	// TTL is how long the token is reused before running the command again. Zero means until it is rejected.
+	TTL time.Duration
+
+	name string
+	args []string
+
+	mu        sync.Mutex
+	token     string
+	fetchedAt time.Time
+}
+
+// NewCommandCredentials returns a provider running name with args to get the token.
+func NewCommandCredentials(name string, args ...string) *CommandCredentials {
+	return &CommandCredentials{name: name, args: args}
+}
+
+// Token returns the cached token, running the command if there is none or it expired.
+func (c *CommandCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.TTL <= 0 || time.Since(c.fetchedAt) < c.TTL) {
+		return c.token, nil
+	}
+
+	return c.run(ctx)
+}
+
+// Refresh runs the command again.
+func (c *CommandCredentials) Refresh(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.run(ctx)
+}
+
+func (c *CommandCredentials) run(ctx context.Context) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("mailinator: credentials command %s: %v: %s", c.name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", errors.New("mailinator: credentials command " + c.name + " printed no token")
+	}
+
+	c.token, c.fetchedAt = token, time.Now()
+	return token, nil
+}
+
+// WithCredentials makes the client get its API token from provider instead of the token passed to NewClient.
+func WithCredentials(provider CredentialProvider) Option {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// token returns the API token to send, or "" if the client has none.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		return "", nil
+	}
+
+	return c.credentials.Token(ctx)
+}
+
+// refreshToken asks the provider for a new token after rejected was refused by the API.
+// It reports whether a different token is available. Calls rejected with the same token at the
+// same time wait for a single refresh and use its token.
+func (c *Client) refreshToken(ctx context.Context, rejected string) (string, bool) {
+	if c.credentials == nil {
+		return "", false
+	}
+
+	if c.refreshing != nil {
+		c.refreshing.Lock()
+		defer c.refreshing.Unlock()
+	}
+
+	// Another call may have refreshed the token while this one waited.
+	token, err := c.credentials.Token(ctx)
+	if refresher, ok := c.credentials.(CredentialRefresher); ok && (err != nil || token == "" || token == rejected) {
+		token, err = refresher.Refresh(ctx)
+	}
+
+	if err != nil || token == "" || token == rejected {
+		return "", false
+	}
+
+	return token, true
+}
+
+// reauthorize sends req again with token after the API rejected the previous one.
+func (c *Client) reauthorize(req *http.Request, token string) (*http.Response, int, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, 0, err
+		}
+		retry.Body = body
+	}
+
+	retry.Header.Set("Authorization", token)
+
+	return c.do(retry)
+}
@@ -0,0 +1,107 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rotatingCredentials supplies token, and next once refreshed.
+type rotatingCredentials struct {
+	mu        sync.Mutex
+	token     string
+	next      string
+	refreshes int32
+}
+
+func (r *rotatingCredentials) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.token, nil
+}
+
+func (r *rotatingCredentials) Refresh(ctx context.Context) (string, error) {
+	atomic.AddInt32(&r.refreshes, 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.token = r.next
+	return r.token, nil
+}
+
+// newTokenServer accepts only token, counting the requests.
+func newTokenServer(hits *int32, token string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.Header.Get("Authorization") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domains":[]}`))
+	}))
+}
+
+func TestRejectedTokenIsRefreshed(t *testing.T) {
+	var hits int32
+	server := newTokenServer(&hits, "new")
+	defer server.Close()
+
+	credentials := &rotatingCredentials{token: "old", next: "new"}
+	c := NewClient("", WithBaseURL(server.URL), WithCredentials(credentials))
+
+	_, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits), "expecting a single re-send")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&credentials.refreshes), "expecting a single refresh")
+}
+
+func TestRejectedTokenIsNotResentUnchanged(t *testing.T) {
+	var hits int32
+	server := newTokenServer(&hits, "new")
+	defer server.Close()
+
+	credentials := &rotatingCredentials{token: "old", next: "old"}
+	c := NewClient("", WithBaseURL(server.URL), WithCredentials(credentials))
+
+	_, err := c.GetDomains()
+	assert.True(t, errors.Is(err, ErrUnauthorized), "expecting unauthorized error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "expecting no re-send")
+}
+
+func TestConcurrentRejectionsRefreshOnce(t *testing.T) {
+	var hits int32
+	server := newTokenServer(&hits, "new")
+	defer server.Close()
+
+	credentials := &rotatingCredentials{token: "old", next: "new"}
+	c := NewClient("", WithBaseURL(server.URL), WithCredentials(credentials))
+
+	const callers = 8
+	var ready, done sync.WaitGroup
+	start := make(chan struct{})
+	ready.Add(callers)
+	done.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+			ready.Done()
+			<-start
+			_, err := c.GetDomains()
+			assert.Nil(t, err, "expecting nil error")
+		}()
+	}
+	ready.Wait()
+	close(start)
+	done.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&credentials.refreshes), "expecting a single refresh")
+}
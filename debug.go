@@ -0,0 +1,152 @@
+package mailinator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// defaultDumpBodyLimit is the number of body bytes kept in a Dump when WithDebug is given zero.
+const defaultDumpBodyLimit = 4096
+
+// Dump is a record of a failed HTTP exchange, with credentials redacted and bodies truncated.
+type Dump struct {
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    string
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   string
+}
+
+// String formats the dump like the HTTP messages it records.
+func (d *Dump) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n", d.Method, d.URL)
+	writeHeader(&b, d.RequestHeader)
+	if d.RequestBody != "" {
+		fmt.Fprintf(&b, "\n%s\n", d.RequestBody)
+	}
+
+	if d.StatusCode == 0 {
+		b.WriteString("\n(no response)\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\n%d %s\n", d.StatusCode, http.StatusText(d.StatusCode))
+	writeHeader(&b, d.ResponseHeader)
+	if d.ResponseBody != "" {
+		fmt.Fprintf(&b, "\n%s\n", d.ResponseBody)
+	}
+
+	return b.String()
+}
+
+func writeHeader(w io.Writer, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(w, "%s: %s\n", key, value)
+		}
+	}
+}
+
+// DebugError wraps the error of a failed call made by a client in debug mode with a dump
+// of the exchange. It is retrieved with errors.As; errors.Is and errors.As see through it.
+type DebugError struct {
+	Err  error
+	Dump Dump
+}
+
+func (e *DebugError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the call.
+func (e *DebugError) Unwrap() error {
+	return e.Err
+}
+
+// WithDebug makes the client wrap the errors of failed calls in a *DebugError holding a dump
+// of the exchange, keeping up to maxBody bytes of each body. Zero means 4096.
+func WithDebug(maxBody int) Option {
+	if maxBody <= 0 {
+		maxBody = defaultDumpBodyLimit
+	}
+
+	return func(c *Client) {
+		c.debugBodyLimit = maxBody
+	}
+}
+
+// decodeError is the error of a response body that could not be decoded, in debug mode.
+type decodeError struct {
+	err  error
+	body []byte
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// debugError wraps err in a *DebugError dumping the exchange of req and res, if the client is in debug mode.
+func (c *Client) debugError(req *http.Request, res *http.Response, err error) error {
+	if c.debugBodyLimit == 0 || err == nil {
+		return err
+	}
+
+	dump := Dump{
+		Method:        req.Method,
+		URL:           redactURL(req.URL),
+		RequestHeader: redactHeader(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if body, bodyErr := req.GetBody(); bodyErr == nil {
+			content, _ := ioutil.ReadAll(body)
+			body.Close()
+			dump.RequestBody = c.truncate(content)
+		}
+	}
+
+	if res != nil {
+		dump.StatusCode = res.StatusCode
+		dump.ResponseHeader = redactHeader(res.Header)
+	}
+
+	var apiErr *APIError
+	var decodeErr *decodeError
+	switch {
+	case errors.As(err, &apiErr):
+		dump.ResponseBody = c.truncate(apiErr.Body)
+	case errors.As(err, &decodeErr):
+		dump.ResponseBody = c.truncate(decodeErr.body)
+	}
+
+	return &DebugError{Err: err, Dump: dump}
+}
+
+// truncate returns body with credentials redacted, cut to the client's dump limit.
+func (c *Client) truncate(body []byte) string {
+	body = redactJSONBody(body)
+	if len(body) <= c.debugBodyLimit {
+		return string(body)
+	}
+
+	return string(body[:c.debugBodyLimit]) + "... (truncated)"
+}
@@ -0,0 +1,81 @@
+package mailinator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugErrorDumpsFailedExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"` + strings.Repeat("x", 64) + `"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("secret-token", WithBaseURL(server.URL), WithDebug(16))
+	_, err := c.PrivateWebhook(&PrivateWebhookOptions{
+		WebhookToken: "secret-webhook-token",
+		Webhook:      Webhook{From: "me", To: "you"},
+	})
+
+	assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+
+	var debugErr *DebugError
+	if !errors.As(err, &debugErr) {
+		t.Fatalf("expecting *DebugError, got %T", err)
+	}
+	dump := debugErr.Dump
+
+	assert.Equal(t, "POST", dump.Method, "expecting request method")
+	assert.Contains(t, dump.URL, "whtoken="+redacted, "expecting redacted webhook token")
+	assert.NotContains(t, dump.URL, "secret-webhook-token", "expecting no webhook token")
+	assert.Equal(t, redacted, dump.RequestHeader.Get("Authorization"), "expecting redacted Authorization header")
+	assert.Equal(t, `{"from":"me","su... (truncated)`, dump.RequestBody, "expecting truncated request body")
+
+	assert.Equal(t, http.StatusInternalServerError, dump.StatusCode, "expecting response status")
+	assert.Equal(t, redacted, dump.ResponseHeader.Get("Set-Cookie"), "expecting redacted Set-Cookie header")
+	assert.Equal(t, "abc", dump.ResponseHeader.Get("X-Request-Id"), "expecting other headers kept")
+	assert.Equal(t, `{"message":"xxxx... (truncated)`, dump.ResponseBody, "expecting truncated response body")
+
+	assert.NotContains(t, dump.String(), "secret", "expecting no credentials in the formatted dump")
+}
+
+func TestDebugErrorDumpsUndecodableBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token":"secret-team-token",`))
+	}))
+	defer server.Close()
+
+	c := NewClient("secret-token", WithBaseURL(server.URL), WithDebug(0))
+	_, err := c.GetTeamInfo()
+
+	var debugErr *DebugError
+	if !errors.As(err, &debugErr) {
+		t.Fatalf("expecting *DebugError, got %T", err)
+	}
+
+	assert.Equal(t, http.StatusOK, debugErr.Dump.StatusCode, "expecting response status")
+	assert.Equal(t, `{"token":"secret-team-token",`, debugErr.Dump.ResponseBody, "expecting the undecodable body")
+}
+
+func TestClientWithoutDebugReturnsPlainErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := NewClient("secret-token", WithBaseURL(server.URL))
+	_, err := c.GetTeamInfo()
+
+	var debugErr *DebugError
+	assert.False(t, errors.As(err, &debugErr), "expecting no dump without WithDebug")
+	assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+}
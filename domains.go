@@ -1,7 +1,7 @@
 package mailinator
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -96,13 +96,18 @@ type ActionData struct {
 
 // Fetches a list of all your domains.
 func (c *Client) GetDomains() (*DomainsList, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains", c.baseURL), nil)
+	return c.GetDomainsWithContext(context.Background())
+}
+
+// GetDomainsWithContext is like GetDomains but carries the given context on the request.
+func (c *Client) GetDomainsWithContext(ctx context.Context) (*DomainsList, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := DomainsList{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetDomains", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -111,13 +116,18 @@ func (c *Client) GetDomains() (*DomainsList, error) {
 
 // Fetches a specific domain
 func (c *Client) GetDomain(options *GetDomainOptions) (*Domain, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s", c.baseURL, options.DomainId), nil)
+	return c.GetDomainWithContext(context.Background(), options)
+}
+
+// GetDomainWithContext is like GetDomain but carries the given context on the request.
+func (c *Client) GetDomainWithContext(ctx context.Context, options *GetDomainOptions) (*Domain, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.DomainId), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Domain{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetDomain", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -126,13 +136,22 @@ func (c *Client) GetDomain(options *GetDomainOptions) (*Domain, error) {
 
 // This endpoint creates a private domain attached to your account. Note, the domain must be unique to the system and you must have not reached your maximum number of Private Domains .
 func (c *Client) CreateDomain(options *CreateDomainOptions) (*ResponseStatus, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/%s", c.baseURL, options.Name), nil)
+	return c.CreateDomainWithContext(context.Background(), options)
+}
+
+// CreateDomainWithContext is like CreateDomain but carries the given context on the request.
+func (c *Client) CreateDomainWithContext(ctx context.Context, options *CreateDomainOptions) (*ResponseStatus, error) {
+	if err := c.guard("CreateDomain", options.Name, ""); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url("domains", options.Name), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatus{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("CreateDomain", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -141,13 +160,30 @@ func (c *Client) CreateDomain(options *CreateDomainOptions) (*ResponseStatus, er
 
 // This endpoint deletes a Private Domain .
 func (c *Client) DeleteDomain(options *DeleteDomainOptions) (*ResponseStatus, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/domains/%s", c.baseURL, options.DomainId), nil)
+	return c.DeleteDomainWithContext(context.Background(), options)
+}
+
+// DeleteDomainWithContext is like DeleteDomain but carries the given context on the request.
+func (c *Client) DeleteDomainWithContext(ctx context.Context, options *DeleteDomainOptions) (*ResponseStatus, error) {
+	if err := c.guardDomainID(ctx, "DeleteDomain", options.DomainId); err != nil {
+		return nil, err
+	}
+
+	if c.isDryRun(ctx) {
+		if _, err := c.previewRules(ctx, "DeleteDomain", options.DomainId, ""); err != nil {
+			return nil, err
+		}
+
+		return &ResponseStatus{Status: DryRunStatus}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url("domains", options.DomainId), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatus{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DeleteDomain", req, &res); err != nil {
 		return nil, err
 	}
 
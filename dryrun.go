@@ -0,0 +1,109 @@
+package mailinator
+
+import (
+	"context"
+)
+
+// DryRunStatus is the status returned by destructive calls made in dry-run mode.
+const DryRunStatus = "dry-run"
+
+// Preview describes what a destructive call would have deleted.
+type Preview struct {
+	// Endpoint is the name of the Client method, e.g. "DeleteAllInboxMessages".
+	Endpoint string
+	// Domain is the domain, or domain id, affected.
+	Domain string
+	// Inbox is the inbox affected, or "*" for every inbox of Domain.
+	Inbox string
+	// MessageCount is the number of messages that would be deleted.
+	MessageCount int
+	// MessageIDs holds the ids of the messages that would be deleted.
+	MessageIDs []string
+	// Rules holds the rules that would be deleted.
+	Rules []Rule
+}
+
+type dryRunKey struct{}
+
+// DryRun returns a context that makes the destructive call it is passed to, DeleteAllDomainMessages,
+// DeleteAllInboxMessages, DeleteDomain or DeleteRule, delete nothing and store what it would have
+// deleted in preview instead. The call returns a result with DryRunStatus as its status.
+func DryRun(ctx context.Context, preview *Preview) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, preview)
+}
+
+// WithDryRun makes every destructive call of the client delete nothing and pass what it would have
+// deleted to report instead, which may be nil. Calls return a result with DryRunStatus as their status.
+func WithDryRun(report func(*Preview)) Option {
+	return func(c *Client) {
+		c.dryRun = true
+		c.reportDryRun = report
+	}
+}
+
+// isDryRun reports whether a destructive call made with ctx must only be previewed.
+func (c *Client) isDryRun(ctx context.Context) bool {
+	if c.dryRun {
+		return true
+	}
+
+	_, ok := ctx.Value(dryRunKey{}).(*Preview)
+	return ok
+}
+
+// previewMessages lists the messages a delete of inbox in domain would remove, paging through FetchInbox.
+func (c *Client) previewMessages(ctx context.Context, endpoint string, domain string, inbox string) (*Preview, error) {
+	preview := &Preview{Endpoint: endpoint, Domain: domain, Inbox: inbox}
+
+	const limit = 50
+	for skip := 0; ; skip += limit {
+		page, err := c.FetchInboxWithContext(ctx, &FetchInboxOptions{Domain: domain, Inbox: inbox, Skip: skip, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, message := range page.Messages {
+			preview.MessageIDs = append(preview.MessageIDs, message.Id)
+		}
+
+		if len(page.Messages) < limit {
+			break
+		}
+	}
+
+	preview.MessageCount = len(preview.MessageIDs)
+	c.report(ctx, preview)
+
+	return preview, nil
+}
+
+// previewRules lists the rules of domainID a delete would remove: all of them, or only ruleID if set.
+func (c *Client) previewRules(ctx context.Context, endpoint string, domainID string, ruleID string) (*Preview, error) {
+	preview := &Preview{Endpoint: endpoint, Domain: domainID}
+
+	rules, err := c.GetAllRulesWithContext(ctx, &GetAllRulesOptions{DomainId: domainID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules.Rules {
+		if ruleID == "" || rule.Id == ruleID {
+			preview.Rules = append(preview.Rules, rule)
+		}
+	}
+
+	c.report(ctx, preview)
+
+	return preview, nil
+}
+
+// report hands preview to the context of the call and to the client's report function.
+func (c *Client) report(ctx context.Context, preview *Preview) {
+	if captured, ok := ctx.Value(dryRunKey{}).(*Preview); ok && captured != nil {
+		*captured = *preview
+	}
+
+	if c.reportDryRun != nil {
+		c.reportDryRun(preview)
+	}
+}
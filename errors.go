@@ -0,0 +1,76 @@
+package mailinator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by *APIError through errors.Is.
+var (
+	ErrBadRequest   = errors.New("mailinator: bad request")
+	ErrUnauthorized = errors.New("mailinator: unauthorized")
+	ErrForbidden    = errors.New("mailinator: forbidden")
+	ErrNotFound     = errors.New("mailinator: not found")
+	ErrRateLimited  = errors.New("mailinator: rate limited")
+	ErrServer       = errors.New("mailinator: server error")
+)
+
+// APIError is returned when the API answers with a non-200 status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the error code reported in the response body, if any.
+	Code int
+	// Message is the error message reported in the response body, if any.
+	Message string
+	// Endpoint is the name of the Client method that failed, e.g. "FetchInbox".
+	Endpoint string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("unknown error, status code: %d", e.StatusCode)
+}
+
+// Is reports whether target is the sentinel error matching the status code of e.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+func newAPIError(endpoint string, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Endpoint:   endpoint,
+		Body:       body,
+	}
+
+	var errRes errorResponse
+	if err := json.Unmarshal(body, &errRes); err == nil {
+		apiErr.Code = errRes.Code
+		apiErr.Message = errRes.Message
+	}
+
+	return apiErr
+}
@@ -0,0 +1,175 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+// ErrPolicyViolation is matched by a *PolicyViolationError.
+var ErrPolicyViolation = errors.New("mailinator: call blocked by guard policy")
+
+// PolicyViolationError is returned without sending the request when a mutating call targets
+// a domain or inbox its GuardPolicy does not allow.
+type PolicyViolationError struct {
+	// Endpoint is the name of the Client method, e.g. "DeleteAllInboxMessages".
+	Endpoint string
+	// Domain is the domain, or domain id, targeted.
+	Domain string
+	// Inbox is the inbox targeted, or "*" for calls affecting the whole domain.
+	Inbox string
+	// Pattern is the Deny pattern matched, or "" if the target matched no Allow pattern.
+	Pattern string
+}
+
+func (e *PolicyViolationError) Error() string {
+	reason := "not allowed"
+	if e.Pattern != "" {
+		reason = "denied by " + e.Pattern
+	}
+
+	return ErrPolicyViolation.Error() + ": " + e.Endpoint + " on " + e.Domain + "/" + e.Inbox + " " + reason
+}
+
+// Is reports whether target is ErrPolicyViolation.
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// GuardPolicy restricts the domains and inboxes mutating calls may affect: deletes, PostMessage,
+// CreateDomain, rule changes, webhook injections, and fetches with Delete set.
+//
+// Patterns are "domain" or "domain/inbox", using path.Match syntax and ignoring case.
+// A pattern without an inbox matches every inbox of the domain. Calls affecting a whole domain,
+// such as DeleteAllDomainMessages or CreateRule, target the inbox "*", so "example.com/test-*"
+// does not allow them. Rule calls and DeleteDomain address the domain by id: the id is resolved
+// to the domain name with GetDomain, and the call is denied if either is denied and allowed if
+// either is allowed. If the id cannot be resolved, the call fails with the error of GetDomain.
+// Trailing dots of domains and patterns are ignored. Webhook injections target the domain "private".
+type GuardPolicy struct {
+	// Allow lists the targets mutating calls may affect. Empty allows every target not denied.
+	Allow []string
+	// Deny lists the targets mutating calls may not affect. It takes precedence over Allow.
+	Deny []string
+}
+
+// WithGuardPolicy makes the client check every mutating call against policy before sending it.
+func WithGuardPolicy(policy GuardPolicy) Option {
+	return func(c *Client) {
+		c.guardPolicy = &policy
+	}
+}
+
+// guard checks a mutating call of endpoint on domain and inbox against the client's GuardPolicy.
+// Calls not targeting a single inbox pass "" as inbox.
+func (c *Client) guard(endpoint string, domain string, inbox string) error {
+	if c.guardPolicy == nil {
+		return nil
+	}
+
+	if inbox == "" {
+		inbox = "*"
+	}
+
+	// Each inbox of a comma-separated list must pass.
+	for _, target := range strings.Split(inbox, ",") {
+		if err := c.guardPolicy.check(endpoint, []string{domain}, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// guardDomainID is like guard for a call of endpoint on every inbox of the domain with the given id,
+// which is checked along with the domain name it resolves to.
+func (c *Client) guardDomainID(ctx context.Context, endpoint string, domainID string) error {
+	if c.guardPolicy == nil {
+		return nil
+	}
+
+	domain, err := c.GetDomainWithContext(ctx, &GetDomainOptions{DomainId: domainID})
+	if err != nil {
+		return err
+	}
+
+	domains := []string{domainID}
+	if domain.Name != "" && !strings.EqualFold(domain.Name, domainID) {
+		domains = append(domains, domain.Name)
+	}
+
+	return c.guardPolicy.check(endpoint, domains, "*")
+}
+
+// check checks inbox of a domain known by any of the given names: it fails if any name is denied,
+// or if none is allowed.
+func (p *GuardPolicy) check(endpoint string, domains []string, inbox string) error {
+	for _, domain := range domains {
+		for _, pattern := range p.Deny {
+			if matchTarget(pattern, domain, inbox, true) {
+				return &PolicyViolationError{Endpoint: endpoint, Domain: domain, Inbox: inbox, Pattern: pattern}
+			}
+		}
+	}
+
+	if len(p.Allow) == 0 {
+		return nil
+	}
+
+	for _, domain := range domains {
+		for _, pattern := range p.Allow {
+			if matchTarget(pattern, domain, inbox, false) {
+				return nil
+			}
+		}
+	}
+
+	return &PolicyViolationError{Endpoint: endpoint, Domain: domains[len(domains)-1], Inbox: inbox}
+}
+
+// matchTarget reports whether pattern matches domain and inbox. When deny is set, wildcard
+// inboxes match every inbox pattern of their domain, since they may cover it, and malformed
+// patterns match everything, so that they deny everything and allow nothing.
+func matchTarget(pattern string, domain string, inbox string, deny bool) bool {
+	domainPattern, inboxPattern := pattern, "*"
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		domainPattern, inboxPattern = pattern[:i], pattern[i+1:]
+	}
+
+	// "example.com." is the fully qualified form of "example.com".
+	domainPattern = strings.TrimRight(domainPattern, ".")
+	domain = strings.TrimRight(domain, ".")
+
+	matched, err := path.Match(strings.ToLower(domainPattern), strings.ToLower(domain))
+	if err != nil {
+		return deny
+	}
+	if !matched {
+		return false
+	}
+
+	if inboxPattern == "*" || (deny && strings.Contains(inbox, "*")) {
+		return true
+	}
+
+	matched, err = path.Match(strings.ToLower(inboxPattern), strings.ToLower(inbox))
+	if err != nil {
+		return deny
+	}
+
+	return matched
+}
+
+// webhookInbox returns the inbox a webhook is injected into: inbox if set, else the local part of to.
+func webhookInbox(inbox string, to string) string {
+	if inbox != "" {
+		return inbox
+	}
+
+	if i := strings.Index(to, "@"); i >= 0 {
+		return to[:i]
+	}
+
+	return to
+}
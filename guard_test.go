@@ -0,0 +1,57 @@
+package mailinator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardPolicyResolvesDomainIDs(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"abc123","name":"prod.example.com"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithGuardPolicy(GuardPolicy{Deny: []string{"prod.example.com"}}))
+
+	_, err := c.DeleteDomain(&DeleteDomainOptions{DomainId: "abc123"})
+	assert.True(t, errors.Is(err, ErrPolicyViolation), "expecting policy violation for DeleteDomain")
+
+	_, err = c.DeleteRule(&DeleteRuleOptions{DomainId: "abc123", RuleId: "rule"})
+	assert.True(t, errors.Is(err, ErrPolicyViolation), "expecting policy violation for DeleteRule")
+
+	assert.Equal(t, []string{"GET", "GET"}, methods, "expecting only domain lookups")
+}
+
+func TestGuardPolicyAllowsResolvedDomainName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			w.Write([]byte(`{"_id":"abc123","name":"test.example.com"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithGuardPolicy(GuardPolicy{Allow: []string{"test.example.com"}}))
+
+	_, err := c.DeleteDomain(&DeleteDomainOptions{DomainId: "abc123"})
+	assert.Nil(t, err, "expecting allowed DeleteDomain")
+}
+
+func TestGuardPolicyIgnoresTrailingDots(t *testing.T) {
+	c := NewClient("token", WithGuardPolicy(GuardPolicy{Deny: []string{"prod.example.com"}}))
+
+	_, err := c.CreateDomain(&CreateDomainOptions{Name: "prod.example.com."})
+	assert.True(t, errors.Is(err, ErrPolicyViolation), "expecting policy violation for fully qualified name")
+
+	_, err = c.DeleteAllInboxMessages(&DeleteAllInboxMessagesOptions{Domain: "Prod.Example.Com.", Inbox: "test"})
+	assert.True(t, errors.Is(err, ErrPolicyViolation), "expecting policy violation ignoring case")
+}
@@ -0,0 +1,212 @@
+package mailinator
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HedgeSettings configures request hedging.
+type HedgeSettings struct {
+	// Delay is how long to wait for a response before sending a second, identical request.
+	Delay time.Duration
+	// DelayFunc, if set, returns the delay for an endpoint, e.g. a latency percentile from Metrics.
+	// Delay is used when it returns zero.
+	DelayFunc func(endpoint string) time.Duration
+	// Endpoints restricts hedging to the named endpoints, e.g. "FetchInboxMessage".
+	// Empty means every GET endpoint. Calls waiting for messages or deleting them are never hedged.
+	Endpoints []string
+	// Budget is the ratio of hedged requests to eligible requests that may not be exceeded. Zero means 0.1.
+	Budget float64
+	// Burst is the number of hedged requests that may be sent before the budget applies. Zero means 10.
+	Burst int
+}
+
+type hedger struct {
+	settings  HedgeSettings
+	endpoints map[string]bool
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// WithHedging makes the client send a second, identical GET request when no response has
+// arrived after a delay. The first response is used and the other request is cancelled.
+// Hedged requests are capped by the budget of settings so they cannot exhaust the API quota.
+func WithHedging(settings HedgeSettings) Option {
+	if settings.Budget <= 0 {
+		settings.Budget = 0.1
+	}
+	if settings.Burst <= 0 {
+		settings.Burst = 10
+	}
+
+	h := &hedger{
+		settings:  settings,
+		endpoints: map[string]bool{},
+		tokens:    float64(settings.Burst),
+	}
+	for _, endpoint := range settings.Endpoints {
+		h.endpoints[endpoint] = true
+	}
+
+	return func(c *Client) {
+		c.hedger = h
+	}
+}
+
+// delay returns the hedging delay for req, or zero if req must not be hedged.
+// Only idempotent GETs are hedged: not those waiting for messages, nor those deleting them.
+func (h *hedger) delay(req *http.Request) time.Duration {
+	query := req.URL.Query()
+	if req.Method != http.MethodGet || query.Get("wait") != "" || query.Get("delete") != "" {
+		return 0
+	}
+
+	endpoint := EndpointFromContext(req.Context())
+	if len(h.endpoints) > 0 && !h.endpoints[endpoint] {
+		return 0
+	}
+
+	if h.settings.DelayFunc != nil {
+		if d := h.settings.DelayFunc(endpoint); d > 0 {
+			return d
+		}
+	}
+
+	return h.settings.Delay
+}
+
+// earn adds the budget share of an eligible request.
+func (h *hedger) earn() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tokens += h.settings.Budget
+	if max := float64(h.settings.Burst); h.tokens > max {
+		h.tokens = max
+	}
+}
+
+// spend reports whether a hedged request may be sent, taking it from the budget.
+func (h *hedger) spend() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tokens < 1 {
+		return false
+	}
+
+	h.tokens--
+	return true
+}
+
+type hedgeResult struct {
+	index  int
+	res    *http.Response
+	err    error
+	cancel context.CancelFunc
+}
+
+// hedge sends req, and an identical request if no response arrived after the hedging delay.
+func (c *Client) hedge(req *http.Request) (*http.Response, error) {
+	if c.hedger == nil {
+		return c.send(req)
+	}
+
+	delay := c.hedger.delay(req)
+	if delay <= 0 {
+		return c.send(req)
+	}
+
+	c.hedger.earn()
+
+	results := make(chan hedgeResult, 2)
+	cancels := make([]context.CancelFunc, 0, 2)
+	launch := func(r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			res, err := c.send(r.WithContext(ctx))
+			results <- hedgeResult{index: index, res: res, err: err, cancel: cancel}
+		}()
+	}
+
+	launch(req)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result.use()
+	case <-timer.C:
+	}
+
+	if !c.hedger.spend() {
+		return (<-results).use()
+	}
+
+	second := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return (<-results).use()
+		}
+		second.Body = body
+	}
+
+	if err := c.wait(second); err != nil {
+		return (<-results).use()
+	}
+
+	launch(second)
+
+	first := <-results
+	if first.err != nil && req.Context().Err() == nil {
+		// Give the other request a chance before reporting the failure.
+		first.discard()
+		return (<-results).use()
+	}
+
+	cancels[1-first.index]()
+	go func() {
+		(<-results).discard()
+	}()
+
+	return first.use()
+}
+
+// use returns the response of r, releasing its context once the body is closed.
+func (r hedgeResult) use() (*http.Response, error) {
+	if r.res == nil {
+		r.cancel()
+		return nil, r.err
+	}
+
+	r.res.Body = &cancelBody{ReadCloser: r.res.Body, cancel: r.cancel}
+	return r.res, r.err
+}
+
+// discard closes the response of a request that lost the race.
+func (r hedgeResult) discard() {
+	r.cancel()
+	if r.res != nil {
+		r.res.Body.Close()
+	}
+}
+
+// cancelBody cancels the context of its request when closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
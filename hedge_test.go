@@ -0,0 +1,76 @@
+package mailinator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgingSendsSecondRequestAfterDelay(t *testing.T) {
+	var gets int32
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&gets, 1) == 1 {
+			// The first request is slow, until the hedged one wins and it is cancelled.
+			select {
+			case <-r.Context().Done():
+				close(cancelled)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domains":[]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithHedging(HedgeSettings{Delay: 50 * time.Millisecond}))
+
+	start := time.Now()
+	_, err := c.GetDomains()
+	elapsed := time.Since(start)
+
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets), "expecting a hedged request")
+	assert.True(t, elapsed >= 50*time.Millisecond, "expecting the hedged request sent after the delay")
+	assert.True(t, elapsed < time.Second, "expecting the hedged response used")
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expecting the slow request cancelled")
+	}
+}
+
+func TestHedgingSkipsFastResponses(t *testing.T) {
+	var gets int32
+	server := newDomainsServer(&gets, nil)
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithHedging(HedgeSettings{Delay: time.Second}))
+
+	_, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting no hedged request")
+}
+
+func TestHedgingSkipsDeletingFetches(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&gets, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"msgs":[]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithHedging(HedgeSettings{Delay: 10 * time.Millisecond}))
+
+	_, err := c.FetchInbox(&FetchInboxOptions{Domain: "d", Inbox: "i", Delete: "1s"})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets), "expecting no hedged request")
+}
@@ -4,7 +4,14 @@
 package mailinator
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -132,6 +139,250 @@ func TestGetDomains(t *testing.T) {
 	}
 }
 
+func TestGetDomainsWithClientOptions(t *testing.T) {
+	c := NewClient(ENV_API_TOKEN,
+		WithBaseURL("https://api.mailinator.com/api/v2/"),
+		WithUserAgent("integration-test"),
+		WithTimeout(30*time.Second),
+		WithHeader("X-Test-Run", GenerateRandomName()),
+	)
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+}
+
+func TestGetDomainsWithFileCredentials(t *testing.T) {
+	file, err := ioutil.TempFile("", "mailinator-token")
+	assert.Nil(t, err, "expecting nil error")
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(ENV_API_TOKEN + "\n")
+	assert.Nil(t, err, "expecting nil error")
+	file.Close()
+
+	c := NewClient("", WithCredentials(NewFileCredentials(file.Name())))
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+}
+
+func TestGetDomainsWithMiddleware(t *testing.T) {
+	var endpoints []string
+	c := NewClient(ENV_API_TOKEN, WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoints = append(endpoints, EndpointFromContext(req.Context()))
+			return next.RoundTrip(req)
+		})
+	}))
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.Equal(t, []string{"GetDomains"}, endpoints, "expecting middleware to see the endpoint")
+}
+
+func TestGetDomainsRecordAndReplay(t *testing.T) {
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette)
+	c := NewClient(ENV_API_TOKEN, WithMiddleware(recorder.Middleware))
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotContains(t, cassette.String(), ENV_API_TOKEN, "expecting redacted api token")
+
+	interactions, err := LoadCassette(&cassette)
+	assert.Nil(t, err, "expecting nil error")
+	assert.Len(t, interactions, 1, "expecting one recorded interaction")
+
+	replayer := NewReplayer(interactions, MatchOptions{})
+	c = NewClient(ENV_API_TOKEN, WithTransport(replayer))
+
+	replayed, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, res, replayed, "expecting replayed result to match recorded result")
+
+	_, err = c.GetDomains()
+	var unmatched *UnmatchedRequestError
+	assert.True(t, errors.As(err, &unmatched), "expecting unmatched request error")
+}
+
+func TestGetDomainsWithCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerSettings{})
+	c := NewClient(ENV_API_TOKEN, WithCircuitBreaker(breaker))
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.Equal(t, BreakerClosed, breaker.State(), "expecting closed circuit breaker")
+}
+
+func TestGetDomainsWithRetryPolicy(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+	c.RetryPolicy = DefaultRetryPolicy()
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+}
+
+func TestGetDomainsWithMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	c := NewClient(ENV_API_TOKEN, WithMetrics(metrics))
+
+	_, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+
+	var out bytes.Buffer
+	err = metrics.WritePrometheus(&out)
+	assert.Nil(t, err, "expecting nil error")
+	assert.Contains(t, out.String(), `mailinator_requests_total{endpoint="GetDomains",status="200"} 1`, "expecting request count")
+	assert.Contains(t, out.String(), `mailinator_request_duration_seconds_count{endpoint="GetDomains"} 1`, "expecting latency histogram")
+}
+
+type testSpan struct {
+	info       SpanInfo
+	statusCode int
+	ended      bool
+}
+
+func (s *testSpan) SpanContext() SpanContext {
+	return SpanContext{TraceID: [16]byte{1}, SpanID: [8]byte{1}, Sampled: true}
+}
+
+func (s *testSpan) End(statusCode int, retries int, err error) {
+	s.statusCode, s.ended = statusCode, true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, info SpanInfo) (context.Context, Span) {
+	span := &testSpan{info: info}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestFetchInboxWithTracer(t *testing.T) {
+	tracer := &testTracer{}
+	c := NewClient(ENV_API_TOKEN, WithTracer(tracer))
+
+	_, err := c.FetchInbox(&FetchInboxOptions{Domain: ENV_DOMAIN_PRIVATE, Inbox: ENV_INBOX})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Len(t, tracer.spans, 1, "expecting one span")
+	assert.Equal(t, "FetchInbox", tracer.spans[0].info.Endpoint, "expecting span endpoint")
+	assert.Equal(t, ENV_DOMAIN_PRIVATE, tracer.spans[0].info.Domain, "expecting span domain")
+	assert.Equal(t, ENV_INBOX, tracer.spans[0].info.Inbox, "expecting span inbox")
+	assert.True(t, tracer.spans[0].ended, "expecting ended span")
+	assert.Equal(t, http.StatusOK, tracer.spans[0].statusCode, "expecting span status code")
+}
+
+func TestGetDomainsWithResponseCache(t *testing.T) {
+	c := NewClient(ENV_API_TOKEN, WithResponseCache(NewResponseCache(CacheSettings{TTL: time.Minute})))
+
+	first, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+
+	second, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, first, second, "expecting cached result")
+}
+
+func TestFetchInboxWithRequestCoalescing(t *testing.T) {
+	c := NewClient(ENV_API_TOKEN, WithRequestCoalescing())
+
+	results := make(chan error, 10)
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			_, err := c.FetchInbox(&FetchInboxOptions{Domain: ENV_DOMAIN_PRIVATE, Inbox: ENV_INBOX})
+			results <- err
+		}()
+	}
+
+	for i := 0; i < cap(results); i++ {
+		assert.Nil(t, <-results, "expecting nil error")
+	}
+}
+
+func TestGetDomainsWithHedging(t *testing.T) {
+	c := NewClient(ENV_API_TOKEN, WithHedging(HedgeSettings{Delay: time.Millisecond, Burst: 1}))
+
+	res, err := c.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+}
+
+func TestFetchInboxWithSpecialCharacters(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	res, err := c.FetchInbox(&FetchInboxOptions{Domain: ENV_DOMAIN_PRIVATE, Inbox: ENV_INBOX + "+tag #1"})
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+}
+
+func TestMockGetDomains(t *testing.T) {
+	mock := &Mock{
+		GetDomainsWithContextFunc: func(ctx context.Context) (*DomainsList, error) {
+			return &DomainsList{Domains: []Domain{{Name: "example.com"}}}, nil
+		},
+	}
+
+	var api API = mock
+	res, err := api.GetDomains()
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, "example.com", res.Domains[0].Name, "expecting stubbed result")
+	assert.Len(t, mock.CallsTo("GetDomains"), 1, "expecting recorded call")
+
+	_, err = api.GetTeam()
+	assert.True(t, errors.Is(err, ErrNotStubbed), "expecting ErrNotStubbed")
+}
+
+func TestDeleteAllInboxMessagesDryRun(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	var preview Preview
+	res, err := c.DeleteAllInboxMessagesWithContext(DryRun(context.Background(), &preview), &DeleteAllInboxMessagesOptions{Domain: ENV_DOMAIN_PRIVATE, Inbox: ENV_INBOX})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, DryRunStatus, res.Status, "expecting dry-run status")
+	assert.Equal(t, preview.MessageCount, res.Count, "expecting previewed count")
+	assert.Len(t, preview.MessageIDs, preview.MessageCount, "expecting previewed message ids")
+}
+
+func TestDeleteAllDomainMessagesWithGuardPolicy(t *testing.T) {
+	c := NewClient(ENV_API_TOKEN, WithGuardPolicy(GuardPolicy{Deny: []string{ENV_DOMAIN_PRIVATE}}))
+
+	_, err := c.DeleteAllDomainMessages(&DeleteAllDomainMessagesOptions{Domain: ENV_DOMAIN_PRIVATE})
+	var violation *PolicyViolationError
+	assert.True(t, errors.As(err, &violation), "expecting *PolicyViolationError")
+	assert.Equal(t, ENV_DOMAIN_PRIVATE, violation.Pattern, "expecting matched pattern")
+}
+
+func TestGetDomainsWithDebug(t *testing.T) {
+	c := NewClient("invalid-token", WithDebug(0))
+
+	_, err := c.GetDomains()
+	var debug *DebugError
+	assert.True(t, errors.As(err, &debug), "expecting *DebugError")
+	if debug != nil {
+		assert.NotEqual(t, 0, debug.Dump.StatusCode, "expecting dumped status code")
+		assert.NotContains(t, debug.Dump.String(), "invalid-token", "expecting redacted token")
+	}
+}
+
+func TestGetDomainsCaptureResponse(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	var meta Response
+	res, err := c.GetDomainsWithContext(CaptureResponse(context.Background(), &meta))
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.Equal(t, http.StatusOK, meta.StatusCode, "expecting captured status code")
+	assert.NotNil(t, meta.Header, "expecting captured headers")
+}
+
 func TestGetDomain(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -180,6 +431,36 @@ func TestGetTeam(t *testing.T) {
 	assert.NotNil(t, res.Id, "expecting non-nil team id result")
 }
 
+func TestGetTeamRedactsToken(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	res, err := c.GetTeam()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+
+	if res != nil && res.Token != "" {
+		assert.NotContains(t, fmt.Sprintf("%v", res), res.Token, "expecting redacted team token")
+	}
+	assert.NotContains(t, fmt.Sprintf("%#v", c), ENV_API_TOKEN, "expecting redacted api token")
+}
+
+func TestGetTeamWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	c := NewClient(ENV_API_TOKEN, WithRateLimiter(limiter))
+
+	res, err := c.GetTeam()
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+
+	if res != nil {
+		limiter.SetDailyReadBudgetFromPlan(res.PlanData)
+	}
+
+	limiter.FailFast = true
+	_, err = c.GetTeam()
+	assert.True(t, errors.Is(err, ErrRateLimited), "expecting client-side rate limit error")
+}
+
 func TestGetTeamInfo(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -188,6 +469,23 @@ func TestGetTeamInfo(t *testing.T) {
 	assert.NotNil(t, res, "expecting non-nil result")
 }
 
+func TestPoolSkipsRevokedToken(t *testing.T) {
+	pool := NewPool([]*Client{
+		NewMailinatorClient(GenerateRandomName()),
+		NewMailinatorClient(ENV_API_TOKEN),
+	}, PoolSettings{Strategy: RoundRobin})
+
+	err := pool.Do(context.Background(), "", func(c *Client) error {
+		_, err := c.GetTeamInfo()
+		return err
+	})
+	assert.Nil(t, err, "expecting nil error")
+
+	status := pool.Status()
+	assert.False(t, status[0].Healthy, "expecting revoked token to be out of rotation")
+	assert.True(t, status[1].Healthy, "expecting valid token to stay in rotation")
+}
+
 // Rules tests.
 func TestCreateRule(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
@@ -336,6 +634,17 @@ func TestFetchInboxWithWaitParam(t *testing.T) {
 	assert.NotNil(t, res, "expecting non-nil result")
 }
 
+func TestFetchInboxWithContextCancelsWait(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := c.FetchInboxWithContext(ctx, &FetchInboxOptions{Domain: domain.Name, Inbox: GenerateRandomName(), Wait: "60s", Limit: 1})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expecting deadline exceeded error")
+	assert.Nil(t, res, "expecting nil result")
+}
+
 func TestFetchInboxMessage(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -352,6 +661,27 @@ func TestFetchMessage(t *testing.T) {
 	assert.NotNil(t, res, "expecting non-nil result")
 }
 
+func TestFetchMessageNotFound(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	res, err := c.FetchMessage(&FetchMessageOptions{domain.Name, GenerateRandomName(), ""})
+	assert.True(t, errors.Is(err, ErrNotFound), "expecting not found error")
+	assert.Nil(t, res, "expecting nil result")
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr), "expecting *APIError") {
+		assert.Equal(t, "FetchMessage", apiErr.Endpoint, "expecting correct endpoint")
+	}
+}
+
+func TestGetDomainsWithInvalidToken(t *testing.T) {
+	c := NewMailinatorClient(GenerateRandomName())
+
+	res, err := c.GetDomains()
+	assert.True(t, errors.Is(err, ErrUnauthorized), "expecting unauthorized error")
+	assert.Nil(t, res, "expecting nil result")
+}
+
 func TestFetchMessageWithDeleteParameter(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -408,6 +738,19 @@ func TestMessageFetchAttachment(t *testing.T) {
 	assert.NotNil(t, res.FileName, "expecting non-nil file name result")
 }
 
+func TestStreamMessageAttachment(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	res, err := c.StreamMessageAttachment(&FetchMessageAttachmentOptions{domain.Name, ENV_MESSAGE_ID_WITH_ATTACHMENT, ENV_ATTACHMENT_ID})
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+
+	if res != nil {
+		defer res.Close()
+		assert.NotEmpty(t, res.ContentType, "expecting non-empty content type result")
+	}
+}
+
 func TestFetchMessageLinks(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -481,6 +824,39 @@ func TestPostMessage(t *testing.T) {
 	}
 }
 
+func TestPostMessageWithWriteQueue(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	dir, err := ioutil.TempDir("", "mailinator-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	results := make(chan QueueResult, 1)
+	q, err := NewWriteQueue(c, QueueSettings{Path: dir + "/writes.log", Results: results})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	message := MessageToPost{
+		Subject: "Testing queued message",
+		From:    "test_email@test.com",
+		Text:    "Hello World!",
+	}
+	id, err := q.PostMessage(&PostMessageOptions{domain.Name, ENV_INBOX_ALL, message})
+	assert.Nil(t, err, "expecting nil error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+
+	result := <-results
+	assert.Equal(t, id, result.ID, "expecting result of queued write")
+	assert.Nil(t, result.Err, "expecting delivered write")
+}
+
 func TestFetchInboxMessageSmtpLog(t *testing.T) {
 	c := NewMailinatorClient(ENV_API_TOKEN)
 
@@ -513,6 +889,16 @@ func TestFetchMessageRaw(t *testing.T) {
 	assert.NotNil(t, res, "expecting non-nil result")
 }
 
+func TestDownloadMessageRaw(t *testing.T) {
+	c := NewMailinatorClient(ENV_API_TOKEN)
+
+	var raw bytes.Buffer
+	res, err := c.DownloadMessageRaw(&FetchMessageRawOptions{domain.Name, message.Id}, &raw)
+	assert.Nil(t, err, "expecting nil error")
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.Equal(t, int64(raw.Len()), res.Size, "expecting size of written body")
+}
+
 //Webhooks tests.
 
 // Common webhook for testing
@@ -592,3 +978,31 @@ func TestPrivateCustomServiceInboxWebhook(t *testing.T) {
 		t.Errorf("PrivateCustomServiceInboxWebhook failed: %v", err)
 	}
 }
+
+func TestWebhookClientPrivateWebhook(t *testing.T) {
+	c := NewWebhookClient(ENV_WEBHOOKTOKEN_PRIVATEDOMAIN)
+
+	res, err := c.PrivateWebhook(&PrivateWebhookOptions{Webhook: testWebhook})
+	if err != nil {
+		t.Errorf("PrivateWebhook failed: %v", err)
+	}
+
+	assert.NotNil(t, res, "expecting non-nil result")
+	if res != nil {
+		assert.Equal(t, "ok", res.Status, "expecting correct status")
+	}
+}
+
+func TestWebhookClientPrivateCustomServiceWebhook(t *testing.T) {
+	c := NewWebhookClient(ENV_WEBHOOKTOKEN_PRIVATEDOMAIN)
+
+	res, err := c.PrivateCustomServiceWebhook(&PrivateCustomServiceWebhookOptions{
+		Webhook:       testWebhook,
+		CustomService: ENV_WEBHOOK_CUSTOMSERVICE,
+	})
+	if err != nil {
+		t.Errorf("PrivateCustomServiceWebhook failed: %v", err)
+	}
+
+	assert.NotNil(t, res, "expecting non-nil result")
+}
@@ -0,0 +1,45 @@
+package mailinator
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger is the structured logger used by a Client. *slog.Logger satisfies it.
+// args are alternating keys and values.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// WithLogger makes the client log every call to logger: successful calls at debug level
+// and failed calls at warn level. Credentials are never logged.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+func (c *Client) logRequest(endpoint string, req *http.Request, statusCode int, retries int, latency time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	args := []interface{}{
+		"method", req.Method,
+		"endpoint", endpoint,
+		"url", redactURL(req.URL),
+		"status", statusCode,
+		"latency", latency,
+		"retries", retries,
+	}
+
+	if err != nil {
+		c.logger.Warn("mailinator request failed", append(args, "error", err.Error())...)
+		return
+	}
+
+	c.logger.Debug("mailinator request", args...)
+}
@@ -2,30 +2,68 @@ package mailinator
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"sync"
 	"time"
 )
 
 // Client .
 type Client struct {
-	apiToken   string
-	baseURL    string
-	HTTPClient *http.Client
+	credentials    CredentialProvider
+	baseURL        string
+	userAgent      string
+	headers        http.Header
+	middlewares    []Middleware
+	logger         Logger
+	limiter        *RateLimiter
+	breaker        *CircuitBreaker
+	metrics        MetricsCollector
+	tracer         Tracer
+	cache          *ResponseCache
+	flights        *flightGroup
+	hedger         *hedger
+	dryRun         bool
+	reportDryRun   func(*Preview)
+	guardPolicy    *GuardPolicy
+	debugBodyLimit int
+	HTTPClient     *http.Client
+
+	// refreshing serializes token refreshes, so that calls rejected at the same time refresh once.
+	refreshing *sync.Mutex
+
+	// RetryPolicy, when set, retries requests that fail with a transport error or a retryable status code.
+	RetryPolicy *RetryPolicy
 }
 
 // NewMailinatorClient creates new Mailinator client with given API Token
 func NewMailinatorClient(apiToken string) *Client {
-	return &Client{
-		apiToken: apiToken,
+	return NewClient(apiToken)
+}
+
+// NewClient creates new Mailinator client with given API Token, configured by the given options
+func NewClient(apiToken string, options ...Option) *Client {
+	c := &Client{
+		baseURL:   defaultBaseURL,
+		userAgent: defaultUserAgent,
+		headers:   http.Header{},
 		HTTPClient: &http.Client{
-			Timeout: 2 * time.Minute,
+			Timeout: defaultTimeout,
 		},
-		baseURL: "https://api.mailinator.com/api/v2",
+		refreshing: &sync.Mutex{},
 	}
+
+	if apiToken != "" {
+		c.credentials = StaticCredentials(apiToken)
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
 }
 
 type errorResponse struct {
@@ -44,57 +82,81 @@ type successResponseWithContent struct {
 	FileName    string `json:"filename"`
 }
 
-func (c *Client) sendRequest(req *http.Request, v interface{}) error {
-	return c.sendRequestWithOptions(req, v, false)
+func (c *Client) sendRequest(endpoint string, req *http.Request, v interface{}) error {
+	return c.sendRequestWithOptions(endpoint, req, v, false)
 }
 
-func (c *Client) sendRequestWithOptions(req *http.Request, v interface{}, returnBody bool) error {
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("Accept", "application/json; charset=utf-8")
+func (c *Client) sendRequestWithOptions(endpoint string, req *http.Request, v interface{}, returnBody bool) error {
+	req = c.startSpan(endpoint, req.WithContext(withEndpoint(req.Context(), endpoint)))
 
-	// Check if apiToken is provided before setting Authorization header
-	if c.apiToken != "" {
-		req.Header.Set("Authorization", c.apiToken)
-	}
+	start := time.Now()
+	res, retries, err := c.exchange(endpoint, req, v, returnBody)
 
-	// Set User-Agent header
-	req.Header.Set("User-Agent", "Mailinator SDK - Go V1.1")
+	return c.finish(endpoint, req, res, retries, time.Since(start), err)
+}
 
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
+// sendStreamRequest is like sendRequest but returns the response with its body unread.
+// The caller must close the body.
+func (c *Client) sendStreamRequest(endpoint string, req *http.Request) (*http.Response, error) {
+	req = c.startSpan(endpoint, req.WithContext(withEndpoint(req.Context(), endpoint)))
 
-	defer res.Body.Close()
+	start := time.Now()
+	res, retries, err := c.roundTrip(endpoint, req)
 
-	if returnBody {
-		responseBodyBytes, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
+	if err = c.finish(endpoint, req, res, retries, time.Since(start), err); err != nil {
+		return nil, err
+	}
 
-		if len(responseBodyBytes) == 0 {
-			return nil
-		}
+	return res, nil
+}
 
-		*v.(*string) = string(responseBodyBytes)
+// finish reports a completed call to the logger, metrics collector and tracer, and to any Response
+// captured by the call context.
+// res is nil if no response was received. It returns err with credentials redacted.
+func (c *Client) finish(endpoint string, req *http.Request, res *http.Response, retries int, elapsed time.Duration, err error) error {
+	err = c.debugError(req, res, redactError(err))
 
-		return nil
+	statusCode := 0
+	if res != nil {
+		statusCode = res.StatusCode
 	}
 
-	responseBody, err := ioutil.ReadAll(res.Body)
+	c.logRequest(endpoint, req, statusCode, retries, elapsed, err)
+	captureResponse(req.Context(), res, retries, elapsed)
+	c.observe(endpoint, req, res, retries, elapsed)
+	endSpan(req, statusCode, retries, err)
+
+	return err
+}
+
+// exchange sends req and decodes the response into v.
+// It returns the response, or nil if none was received, and the number of retries made.
+func (c *Client) exchange(endpoint string, req *http.Request, v interface{}, returnBody bool) (*http.Response, int, error) {
+	res, responseBody, retries, err := c.read(endpoint, req)
 	if err != nil {
-		return err
+		return res, retries, err
+	}
+
+	if err := decodeResponse(res, responseBody, v, returnBody); err != nil {
+		if c.debugBodyLimit > 0 {
+			err = &decodeError{err: err, body: responseBody}
+		}
+		return res, retries, err
 	}
 
-	// Try to unmarshall into errorResponse
-	if res.StatusCode != http.StatusOK {
-		var errRes errorResponse
-		if err = json.Unmarshal(responseBody, &errRes); err == nil {
-			return errors.New(errRes.Message)
+	return res, retries, nil
+}
+
+// decodeResponse decodes responseBody into v, or stores it as a string in v if returnBody is set.
+func decodeResponse(res *http.Response, responseBody []byte, v interface{}, returnBody bool) error {
+	if returnBody {
+		if len(responseBody) == 0 {
+			return nil
 		}
 
-		return fmt.Errorf("unknown error, status code: %d", res.StatusCode)
+		*v.(*string) = string(responseBody)
+
+		return nil
 	}
 
 	contentType := res.Header.Get("Content-Type")
@@ -129,9 +191,66 @@ func (c *Client) sendRequestWithOptions(req *http.Request, v interface{}, return
 		return nil
 	}
 
-	if err = json.Unmarshal(responseBody, &v); err != nil {
-		return err
+	return json.Unmarshal(responseBody, &v)
+}
+
+// roundTrip sends req with the client's headers and returns the response with its body unread.
+// Non-200 responses, other than 304 to a conditional request, are closed and reported as an *APIError,
+// along with the response.
+func (c *Client) roundTrip(endpoint string, req *http.Request) (*http.Response, int, error) {
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+
+	token, err := c.token(req.Context())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Check if a token is provided before setting Authorization header
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	// Set User-Agent header
+	req.Header.Set("User-Agent", c.userAgent)
+
+	res, retries, err := c.do(req)
+	if err != nil {
+		return nil, retries, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && token != "" {
+		if fresh, ok := c.refreshToken(req.Context(), token); ok {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+
+			var more int
+			res, more, err = c.reauthorize(req, fresh)
+			retries += more + 1
+			if err != nil {
+				return nil, retries, err
+			}
+		}
+	}
+
+	if res.StatusCode != http.StatusOK && !(res.StatusCode == http.StatusNotModified && conditional(req)) {
+		defer res.Body.Close()
+
+		responseBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return res, retries, err
+		}
+
+		return res, retries, newAPIError(endpoint, res, responseBody)
+	}
+
+	if c.metrics != nil {
+		res.Body = &countingBody{ReadCloser: res.Body}
 	}
 
-	return nil
+	return res, retries, nil
 }
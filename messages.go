@@ -2,9 +2,10 @@ package mailinator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"strconv"
 )
 
 // FetchInboxOptions .
@@ -278,6 +279,17 @@ type FetchLatestInboxMessagesOptions struct {
 
 // Retrieves a list of messages summaries. You can retreive a list by inbox, inboxes, or entire domain.
 func (c *Client) FetchInbox(options *FetchInboxOptions) (*Inbox, error) {
+	return c.FetchInboxWithContext(context.Background(), options)
+}
+
+// FetchInboxWithContext is like FetchInbox but carries the given context on the request.
+func (c *Client) FetchInboxWithContext(ctx context.Context, options *FetchInboxOptions) (*Inbox, error) {
+	if options.Delete != "" {
+		if err := c.guard("FetchInbox", options.Domain, options.Inbox); err != nil {
+			return nil, err
+		}
+	}
+
 	skip := 0
 	limit := 50
 	sort := Sort("ascending")
@@ -303,31 +315,35 @@ func (c *Client) FetchInbox(options *FetchInboxOptions) (*Inbox, error) {
 
 	var buf bytes.Buffer
 
-	url := fmt.Sprintf("%s/domains/%s/inboxes/%s?skip=%d&limit=%d&sort=%v&decode_subject=%t", c.baseURL, options.Domain, options.Inbox, skip, limit, sort, decodeSubject)
+	url := c.url("domains", options.Domain, "inboxes", options.Inbox)
+	url = addQuery(url, "skip", strconv.Itoa(skip))
+	url = addQuery(url, "limit", strconv.Itoa(limit))
+	url = addQuery(url, "sort", string(sort))
+	url = addQuery(url, "decode_subject", strconv.FormatBool(decodeSubject))
 
 	if options.Cursor != "" {
-		url = fmt.Sprintf("%s&cursor=%s", url, options.Cursor)
+		url = addQuery(url, "cursor", options.Cursor)
 	}
 
 	if options.Full != false {
-		url = fmt.Sprintf("%s&full=%s", url, "true")
+		url = addQuery(url, "full", "true")
 	}
 
 	if options.Delete != "" {
-		url = fmt.Sprintf("%s&delete=%s", url, options.Delete)
+		url = addQuery(url, "delete", options.Delete)
 	}
 
 	if options.Wait != "" {
-		url = fmt.Sprintf("%s&wait=%s", url, options.Wait)
+		url = addQuery(url, "wait", options.Wait)
 	}
 
-	req, err := http.NewRequest("GET", url, &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Inbox{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInbox", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -336,15 +352,20 @@ func (c *Client) FetchInbox(options *FetchInboxOptions) (*Inbox, error) {
 
 // Retrieves a specific message by id for specific inbox.
 func (c *Client) FetchInboxMessage(options *FetchInboxMessageOptions) (*Message, error) {
+	return c.FetchInboxMessageWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageWithContext is like FetchInboxMessage but carries the given context on the request.
+func (c *Client) FetchInboxMessageWithContext(ctx context.Context, options *FetchInboxMessageOptions) (*Message, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Message{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInboxMessage", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -353,21 +374,32 @@ func (c *Client) FetchInboxMessage(options *FetchInboxMessageOptions) (*Message,
 
 // Retrieves a specific message by id.
 func (c *Client) FetchMessage(options *FetchMessageOptions) (*Message, error) {
+	return c.FetchMessageWithContext(context.Background(), options)
+}
+
+// FetchMessageWithContext is like FetchMessage but carries the given context on the request.
+func (c *Client) FetchMessageWithContext(ctx context.Context, options *FetchMessageOptions) (*Message, error) {
+	if options.Delete != "" {
+		if err := c.guard("FetchMessage", options.Domain, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	var buf bytes.Buffer
 
-	url := fmt.Sprintf("%s/domains/%s/messages/%s", c.baseURL, options.Domain, options.MessageId)
+	url := c.url("domains", options.Domain, "messages", options.MessageId)
 
 	if options.Delete != "" {
-		url = fmt.Sprintf("%s?delete=%s", url, options.Delete)
+		url = addQuery(url, "delete", options.Delete)
 	}
 
-	req, err := http.NewRequest("GET", url, &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Message{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessage", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -376,15 +408,20 @@ func (c *Client) FetchMessage(options *FetchMessageOptions) (*Message, error) {
 
 // Retrieves a specific SMS message by sms number.
 func (c *Client) FetchSMSMessage(options *FetchSMSMessageOptions) (*SMSMessage, error) {
+	return c.FetchSMSMessageWithContext(context.Background(), options)
+}
+
+// FetchSMSMessageWithContext is like FetchSMSMessage but carries the given context on the request.
+func (c *Client) FetchSMSMessageWithContext(ctx context.Context, options *FetchSMSMessageOptions) (*SMSMessage, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s", c.baseURL, options.Domain, options.TeamSMSNumber), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.TeamSMSNumber), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := SMSMessage{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchSMSMessage", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -393,15 +430,20 @@ func (c *Client) FetchSMSMessage(options *FetchSMSMessageOptions) (*SMSMessage,
 
 // Retrieves a list of attachments for a message for specific inbox. Note attachments are expected to be in Email format.
 func (c *Client) FetchInboxMessageAtachments(options *FetchInboxMessageAttachmentsOptions) (*Attachments, error) {
+	return c.FetchInboxMessageAtachmentsWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageAtachmentsWithContext is like FetchInboxMessageAtachments but carries the given context on the request.
+func (c *Client) FetchInboxMessageAtachmentsWithContext(ctx context.Context, options *FetchInboxMessageAttachmentsOptions) (*Attachments, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s/attachments", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "attachments"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Attachments{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInboxMessageAtachments", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -410,15 +452,20 @@ func (c *Client) FetchInboxMessageAtachments(options *FetchInboxMessageAttachmen
 
 // Retrieves a list of attachments for a message. Note attachments are expected to be in Email format.
 func (c *Client) FetchMessageAtachments(options *FetchMessageAttachmentsOptions) (*Attachments, error) {
+	return c.FetchMessageAtachmentsWithContext(context.Background(), options)
+}
+
+// FetchMessageAtachmentsWithContext is like FetchMessageAtachments but carries the given context on the request.
+func (c *Client) FetchMessageAtachmentsWithContext(ctx context.Context, options *FetchMessageAttachmentsOptions) (*Attachments, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/attachments", c.baseURL, options.Domain, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "attachments"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Attachments{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessageAtachments", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -427,15 +474,20 @@ func (c *Client) FetchMessageAtachments(options *FetchMessageAttachmentsOptions)
 
 // Retrieves a specific attachment for specific inbox .
 func (c *Client) FetchInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	return c.FetchInboxMessageAttachmentWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageAttachmentWithContext is like FetchInboxMessageAttachment but carries the given context on the request.
+func (c *Client) FetchInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s/attachments/%d", c.baseURL, options.Domain, options.Inbox, options.MessageId, options.AttachmentId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "attachments", strconv.Itoa(options.AttachmentId)), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := FetchAttachmentResponse{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInboxMessageAttachment", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -444,15 +496,20 @@ func (c *Client) FetchInboxMessageAttachment(options *FetchInboxMessageAttachmen
 
 // Retrieves a specific attachment.
 func (c *Client) FetchMessageAttachment(options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	return c.FetchMessageAttachmentWithContext(context.Background(), options)
+}
+
+// FetchMessageAttachmentWithContext is like FetchMessageAttachment but carries the given context on the request.
+func (c *Client) FetchMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/attachments/%d", c.baseURL, options.Domain, options.MessageId, options.AttachmentId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "attachments", strconv.Itoa(options.AttachmentId)), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := FetchAttachmentResponse{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessageAttachment", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -461,15 +518,20 @@ func (c *Client) FetchMessageAttachment(options *FetchMessageAttachmentOptions)
 
 // Retrieves all links found within a given email
 func (c *Client) FetchMessageLinks(options *FetchMessageLinksOptions) (*MessageLinks, error) {
+	return c.FetchMessageLinksWithContext(context.Background(), options)
+}
+
+// FetchMessageLinksWithContext is like FetchMessageLinks but carries the given context on the request.
+func (c *Client) FetchMessageLinksWithContext(ctx context.Context, options *FetchMessageLinksOptions) (*MessageLinks, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/links", c.baseURL, options.Domain, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "links"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := MessageLinks{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessageLinks", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -478,15 +540,20 @@ func (c *Client) FetchMessageLinks(options *FetchMessageLinksOptions) (*MessageL
 
 // Retrieves all links full found within a given email
 func (c *Client) FetchMessageLinksFull(options *FetchMessageLinksFullOptions) (*MessageLinksFull, error) {
+	return c.FetchMessageLinksFullWithContext(context.Background(), options)
+}
+
+// FetchMessageLinksFullWithContext is like FetchMessageLinksFull but carries the given context on the request.
+func (c *Client) FetchMessageLinksFullWithContext(ctx context.Context, options *FetchMessageLinksFullOptions) (*MessageLinksFull, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/linksfull", c.baseURL, options.Domain, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "linksfull"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := MessageLinksFull{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessageLinksFull", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -495,15 +562,20 @@ func (c *Client) FetchMessageLinksFull(options *FetchMessageLinksFullOptions) (*
 
 // Retrieves all links found within a given email for specific inbox .
 func (c *Client) FetchInboxMessageLinks(options *FetchInboxMessageLinksOptions) (*MessageLinks, error) {
+	return c.FetchInboxMessageLinksWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageLinksWithContext is like FetchInboxMessageLinks but carries the given context on the request.
+func (c *Client) FetchInboxMessageLinksWithContext(ctx context.Context, options *FetchInboxMessageLinksOptions) (*MessageLinks, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s/links", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "links"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := MessageLinks{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInboxMessageLinks", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -512,15 +584,33 @@ func (c *Client) FetchInboxMessageLinks(options *FetchInboxMessageLinksOptions)
 
 // Deletes ALL messages from a Private Domain. Caution: This action is irreversible.
 func (c *Client) DeleteAllDomainMessages(options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error) {
+	return c.DeleteAllDomainMessagesWithContext(context.Background(), options)
+}
+
+// DeleteAllDomainMessagesWithContext is like DeleteAllDomainMessages but carries the given context on the request.
+func (c *Client) DeleteAllDomainMessagesWithContext(ctx context.Context, options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error) {
+	if err := c.guard("DeleteAllDomainMessages", options.Domain, ""); err != nil {
+		return nil, err
+	}
+
+	if c.isDryRun(ctx) {
+		preview, err := c.previewMessages(ctx, "DeleteAllDomainMessages", options.Domain, "*")
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeletedMessages{Status: DryRunStatus, Count: preview.MessageCount}, nil
+	}
+
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/domains/%s/inboxes", c.baseURL, options.Domain), &buf)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url("domains", options.Domain, "inboxes"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := DeletedMessages{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DeleteAllDomainMessages", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -529,15 +619,33 @@ func (c *Client) DeleteAllDomainMessages(options *DeleteAllDomainMessagesOptions
 
 // Deletes ALL messages from a specific private inbox.
 func (c *Client) DeleteAllInboxMessages(options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error) {
+	return c.DeleteAllInboxMessagesWithContext(context.Background(), options)
+}
+
+// DeleteAllInboxMessagesWithContext is like DeleteAllInboxMessages but carries the given context on the request.
+func (c *Client) DeleteAllInboxMessagesWithContext(ctx context.Context, options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error) {
+	if err := c.guard("DeleteAllInboxMessages", options.Domain, options.Inbox); err != nil {
+		return nil, err
+	}
+
+	if c.isDryRun(ctx) {
+		preview, err := c.previewMessages(ctx, "DeleteAllInboxMessages", options.Domain, options.Inbox)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeletedMessages{Status: DryRunStatus, Count: preview.MessageCount}, nil
+	}
+
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/domains/%s/inboxes/%s", c.baseURL, options.Domain, options.Inbox), &buf)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url("domains", options.Domain, "inboxes", options.Inbox), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := DeletedMessages{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DeleteAllInboxMessages", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -546,15 +654,24 @@ func (c *Client) DeleteAllInboxMessages(options *DeleteAllInboxMessagesOptions)
 
 // Deletes a specific messages
 func (c *Client) DeleteMessage(options *DeleteMessageOptions) (*DeletedMessages, error) {
+	return c.DeleteMessageWithContext(context.Background(), options)
+}
+
+// DeleteMessageWithContext is like DeleteMessage but carries the given context on the request.
+func (c *Client) DeleteMessageWithContext(ctx context.Context, options *DeleteMessageOptions) (*DeletedMessages, error) {
+	if err := c.guard("DeleteMessage", options.Domain, options.Inbox); err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := DeletedMessages{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DeleteMessage", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -563,15 +680,24 @@ func (c *Client) DeleteMessage(options *DeleteMessageOptions) (*DeletedMessages,
 
 // Deliver a JSON message into your private domain.
 func (c *Client) PostMessage(options *PostMessageOptions) (*PostedMessage, error) {
+	return c.PostMessageWithContext(context.Background(), options)
+}
+
+// PostMessageWithContext is like PostMessage but carries the given context on the request.
+func (c *Client) PostMessageWithContext(ctx context.Context, options *PostMessageOptions) (*PostedMessage, error) {
+	if err := c.guard("PostMessage", options.Domain, options.Inbox); err != nil {
+		return nil, err
+	}
+
 	jsonReq, _ := json.Marshal(options.Message)
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages", c.baseURL, options.Domain, options.Inbox), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages"), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return nil, err
 	}
 
 	res := PostedMessage{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("PostMessage", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -580,15 +706,20 @@ func (c *Client) PostMessage(options *PostMessageOptions) (*PostedMessage, error
 
 // This endpoint retrieves smtp log from the email .
 func (c *Client) FetchMessageSmtpLog(options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	return c.FetchMessageSmtpLogWithContext(context.Background(), options)
+}
+
+// FetchMessageSmtpLogWithContext is like FetchMessageSmtpLog but carries the given context on the request.
+func (c *Client) FetchMessageSmtpLogWithContext(ctx context.Context, options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/smtplog", c.baseURL, options.Domain, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "smtplog"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := MessageSmtpLogs{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchMessageSmtpLog", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -597,15 +728,20 @@ func (c *Client) FetchMessageSmtpLog(options *FetchMessageSmtpLogOptions) (*Mess
 
 // This endpoint retrieves smtp log from the email for specific inbox .
 func (c *Client) FetchInboxMessageSmtpLog(options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	return c.FetchInboxMessageSmtpLogWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageSmtpLogWithContext is like FetchInboxMessageSmtpLog but carries the given context on the request.
+func (c *Client) FetchInboxMessageSmtpLogWithContext(ctx context.Context, options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s/smtplog", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "smtplog"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := MessageSmtpLogs{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchInboxMessageSmtpLog", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -614,15 +750,20 @@ func (c *Client) FetchInboxMessageSmtpLog(options *FetchInboxMessageSmtpLogOptio
 
 // This endpoint retrieves raw info from the email .
 func (c *Client) FetchMessageRaw(options *FetchMessageRawOptions) (*string, error) {
+	return c.FetchMessageRawWithContext(context.Background(), options)
+}
+
+// FetchMessageRawWithContext is like FetchMessageRaw but carries the given context on the request.
+func (c *Client) FetchMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*string, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/%s/raw", c.baseURL, options.Domain, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "raw"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := new(string)
-	if err := c.sendRequestWithOptions(req, res, true); err != nil {
+	if err := c.sendRequestWithOptions("FetchMessageRaw", req, res, true); err != nil {
 		return nil, err
 	}
 
@@ -631,15 +772,20 @@ func (c *Client) FetchMessageRaw(options *FetchMessageRawOptions) (*string, erro
 
 // This endpoint retrieves raw info from the email for specific inbox .
 func (c *Client) FetchInboxMessageRaw(options *FetchInboxMessageRawOptions) (*string, error) {
+	return c.FetchInboxMessageRawWithContext(context.Background(), options)
+}
+
+// FetchInboxMessageRawWithContext is like FetchInboxMessageRaw but carries the given context on the request.
+func (c *Client) FetchInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*string, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/%s/raw", c.baseURL, options.Domain, options.Inbox, options.MessageId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "raw"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := new(string)
-	if err := c.sendRequestWithOptions(req, res, true); err != nil {
+	if err := c.sendRequestWithOptions("FetchInboxMessageRaw", req, res, true); err != nil {
 		return nil, err
 	}
 
@@ -648,15 +794,20 @@ func (c *Client) FetchInboxMessageRaw(options *FetchInboxMessageRawOptions) (*st
 
 // That fetches the latest 5 FULL messages .
 func (c *Client) FetchLatestMessages(options *FetchLatestMessagesOptions) (*Inbox, error) {
+	return c.FetchLatestMessagesWithContext(context.Background(), options)
+}
+
+// FetchLatestMessagesWithContext is like FetchLatestMessages but carries the given context on the request.
+func (c *Client) FetchLatestMessagesWithContext(ctx context.Context, options *FetchLatestMessagesOptions) (*Inbox, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/messages/*", c.baseURL, options.Domain), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", "*"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Inbox{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchLatestMessages", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -665,15 +816,20 @@ func (c *Client) FetchLatestMessages(options *FetchLatestMessagesOptions) (*Inbo
 
 // That fetches the latest 5 FULL messages for specific inbox .
 func (c *Client) FetchLatestInboxMessages(options *FetchLatestInboxMessagesOptions) (*Inbox, error) {
+	return c.FetchLatestInboxMessagesWithContext(context.Background(), options)
+}
+
+// FetchLatestInboxMessagesWithContext is like FetchLatestInboxMessages but carries the given context on the request.
+func (c *Client) FetchLatestInboxMessagesWithContext(ctx context.Context, options *FetchLatestInboxMessagesOptions) (*Inbox, error) {
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/inboxes/%s/messages/*", c.baseURL, options.Domain, options.Inbox), &buf)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", "*"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Inbox{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("FetchLatestInboxMessages", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,273 @@
+package mailinator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describes a completed Client call.
+type RequestMetrics struct {
+	// Endpoint is the name of the Client method, e.g. "FetchInbox".
+	Endpoint string
+	// Method is the HTTP method.
+	Method string
+	// StatusCode is the status code of the last response, or 0 if none was received.
+	StatusCode int
+	// Elapsed is the total duration of the call, including retries.
+	Elapsed time.Duration
+	// Retries is the number of retries made.
+	Retries int
+	// BytesRead is the size of the response body. For streamed downloads it is the announced Content-Length, if any.
+	BytesRead int64
+	// LongPoll is set for calls that asked the API to wait for messages, e.g. FetchInbox with Wait.
+	LongPoll bool
+	// Cached is set for calls answered from the client's ResponseCache without sending a request.
+	Cached bool
+}
+
+// MetricsCollector receives the metrics of every Client call. It must be safe for concurrent use.
+type MetricsCollector interface {
+	ObserveRequest(m RequestMetrics)
+}
+
+// WithMetrics makes the client report every call to collector.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = collector
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histograms of Metrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// Metrics is an in-memory MetricsCollector that serves its metrics in the Prometheus text format.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[[2]string]uint64
+	retries   map[string]uint64
+	bytesRead map[string]uint64
+	cacheHits map[string]uint64
+	latency   map[string]*histogram
+	longPoll  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, value float64) {
+	for i, bound := range buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+// NewMetrics returns an empty Metrics using DefaultLatencyBuckets.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets:   DefaultLatencyBuckets,
+		requests:  map[[2]string]uint64{},
+		retries:   map[string]uint64{},
+		bytesRead: map[string]uint64{},
+		cacheHits: map[string]uint64{},
+		latency:   map[string]*histogram{},
+		longPoll:  map[string]*histogram{},
+	}
+}
+
+// ObserveRequest records a completed call. Calls answered from cache are only counted as cache hits.
+func (m *Metrics) ObserveRequest(r RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.Cached {
+		m.cacheHits[r.Endpoint]++
+		return
+	}
+
+	m.requests[[2]string{r.Endpoint, strconv.Itoa(r.StatusCode)}]++
+	m.retries[r.Endpoint] += uint64(r.Retries)
+	if r.BytesRead > 0 {
+		m.bytesRead[r.Endpoint] += uint64(r.BytesRead)
+	}
+
+	histograms := m.latency
+	if r.LongPoll {
+		histograms = m.longPoll
+	}
+
+	h, ok := histograms[r.Endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		histograms[r.Endpoint] = h
+	}
+	h.observe(m.buckets, r.Elapsed.Seconds())
+}
+
+// Quantile estimates the q-quantile, e.g. 0.95, of the latency of endpoint from its histogram,
+// excluding calls waiting for messages. It returns zero if no call was observed.
+func (m *Metrics) Quantile(endpoint string, q float64) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.latency[endpoint]
+	if !ok || h.count == 0 {
+		return 0
+	}
+
+	rank := q * float64(h.count)
+	lower, below := 0.0, uint64(0)
+	for i, bound := range m.buckets {
+		if float64(h.counts[i]) >= rank {
+			inBucket := h.counts[i] - below
+			if inBucket == 0 {
+				return seconds(bound)
+			}
+			return seconds(lower + (bound-lower)*(rank-float64(below))/float64(inBucket))
+		}
+		lower, below = bound, h.counts[i]
+	}
+
+	return seconds(lower)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintln(buf, "# HELP mailinator_requests_total Client calls by endpoint and status code.")
+	fmt.Fprintln(buf, "# TYPE mailinator_requests_total counter")
+	keys := make([][2]string, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		fmt.Fprintf(buf, "mailinator_requests_total{endpoint=%s,status=%s} %d\n", quoteLabel(key[0]), quoteLabel(key[1]), m.requests[key])
+	}
+
+	writeCounter(buf, "mailinator_retries_total", "Retries by endpoint.", m.retries)
+	writeCounter(buf, "mailinator_response_bytes_total", "Response body bytes downloaded by endpoint.", m.bytesRead)
+	writeCounter(buf, "mailinator_cache_hits_total", "Calls answered from cache by endpoint.", m.cacheHits)
+	m.writeHistogram(buf, "mailinator_request_duration_seconds", "Duration of client calls by endpoint.", m.latency)
+	m.writeHistogram(buf, "mailinator_long_poll_duration_seconds", "Duration of client calls waiting for messages by endpoint.", m.longPoll)
+
+	return buf.Flush()
+}
+
+func writeCounter(w io.Writer, name, help string, values map[string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, endpoint := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s{endpoint=%s} %d\n", name, quoteLabel(endpoint), values[endpoint])
+	}
+}
+
+func (m *Metrics) writeHistogram(w io.Writer, name, help string, histograms map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	endpoints := make([]string, 0, len(histograms))
+	for endpoint := range histograms {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	for _, endpoint := range endpoints {
+		h := histograms[endpoint]
+		label := quoteLabel(endpoint)
+		for i, bound := range m.buckets {
+			fmt.Fprintf(w, "%s_bucket{endpoint=%s,le=\"%s\"} %d\n", name, label, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{endpoint=%s,le=\"+Inf\"} %d\n", name, label, h.count)
+		fmt.Fprintf(w, "%s_sum{endpoint=%s} %s\n", name, label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{endpoint=%s} %d\n", name, label, h.count)
+	}
+}
+
+func sortedKeys(values map[string]uint64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+// observe reports a completed call to the client's MetricsCollector.
+func (c *Client) observe(endpoint string, req *http.Request, res *http.Response, retries int, elapsed time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+
+	m := RequestMetrics{
+		Endpoint: endpoint,
+		Method:   req.Method,
+		Elapsed:  elapsed,
+		Retries:  retries,
+		LongPoll: req.URL.Query().Get("wait") != "",
+	}
+
+	if res != nil {
+		m.StatusCode = res.StatusCode
+		_, m.Cached = res.Body.(cachedBody)
+		// Responses shared by coalesced callers are counted by the caller that made the request.
+		_, isShared := res.Body.(sharedBody)
+		if body, ok := res.Body.(*countingBody); ok && body.n > 0 {
+			m.BytesRead = body.n
+		} else if res.ContentLength > 0 && !isShared {
+			m.BytesRead = res.ContentLength
+		}
+	}
+
+	c.metrics.ObserveRequest(m)
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,55 @@
+package mailinator
+
+import (
+	"context"
+	"net/http"
+)
+
+// Middleware wraps the round trip of every request sent by a Client.
+// It can inspect or modify the outgoing request, e.g. to add headers or sign it,
+// and inspect or replace the response. EndpointFromContext(req.Context())
+// returns the name of the Client method the request belongs to.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middlewares to the client. The first middleware is the outermost one.
+// Middlewares run once per attempt, so retried requests pass through them again.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+type endpointKey struct{}
+
+func withEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// EndpointFromContext returns the name of the Client method, e.g. "FetchInbox",
+// that issued the request carrying ctx, or "" if there is none.
+func EndpointFromContext(ctx context.Context) string {
+	endpoint, _ := ctx.Value(endpointKey{}).(string)
+	return endpoint
+}
+
+// send passes a single attempt of req through the middleware chain.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if len(c.middlewares) == 0 {
+		return c.HTTPClient.Do(req)
+	}
+
+	var transport http.RoundTripper = RoundTripperFunc(c.HTTPClient.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+
+	return transport.RoundTrip(req.Clone(req.Context()))
+}
@@ -0,0 +1,1245 @@
+// Code generated by mock_gen.go; DO NOT EDIT.
+
+package mailinator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Mock is an API for tests. Each method calls the function in the field of the same name
+// with a Func suffix. When it is not set, a method without context falls back to the
+// WithContext function, and otherwise an error matching ErrNotStubbed is returned.
+// Every call is recorded. A Mock is safe for concurrent use once its functions are set.
+type Mock struct {
+	FetchInboxFunc                                  func(options *FetchInboxOptions) (*Inbox, error)
+	FetchInboxWithContextFunc                       func(ctx context.Context, options *FetchInboxOptions) (*Inbox, error)
+	FetchInboxMessageFunc                           func(options *FetchInboxMessageOptions) (*Message, error)
+	FetchInboxMessageWithContextFunc                func(ctx context.Context, options *FetchInboxMessageOptions) (*Message, error)
+	FetchMessageFunc                                func(options *FetchMessageOptions) (*Message, error)
+	FetchMessageWithContextFunc                     func(ctx context.Context, options *FetchMessageOptions) (*Message, error)
+	FetchSMSMessageFunc                             func(options *FetchSMSMessageOptions) (*SMSMessage, error)
+	FetchSMSMessageWithContextFunc                  func(ctx context.Context, options *FetchSMSMessageOptions) (*SMSMessage, error)
+	FetchInboxMessageAtachmentsFunc                 func(options *FetchInboxMessageAttachmentsOptions) (*Attachments, error)
+	FetchInboxMessageAtachmentsWithContextFunc      func(ctx context.Context, options *FetchInboxMessageAttachmentsOptions) (*Attachments, error)
+	FetchMessageAtachmentsFunc                      func(options *FetchMessageAttachmentsOptions) (*Attachments, error)
+	FetchMessageAtachmentsWithContextFunc           func(ctx context.Context, options *FetchMessageAttachmentsOptions) (*Attachments, error)
+	FetchInboxMessageAttachmentFunc                 func(options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchInboxMessageAttachmentWithContextFunc      func(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageAttachmentFunc                      func(options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageAttachmentWithContextFunc           func(ctx context.Context, options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error)
+	FetchMessageLinksFunc                           func(options *FetchMessageLinksOptions) (*MessageLinks, error)
+	FetchMessageLinksWithContextFunc                func(ctx context.Context, options *FetchMessageLinksOptions) (*MessageLinks, error)
+	FetchMessageLinksFullFunc                       func(options *FetchMessageLinksFullOptions) (*MessageLinksFull, error)
+	FetchMessageLinksFullWithContextFunc            func(ctx context.Context, options *FetchMessageLinksFullOptions) (*MessageLinksFull, error)
+	FetchInboxMessageLinksFunc                      func(options *FetchInboxMessageLinksOptions) (*MessageLinks, error)
+	FetchInboxMessageLinksWithContextFunc           func(ctx context.Context, options *FetchInboxMessageLinksOptions) (*MessageLinks, error)
+	DeleteAllDomainMessagesFunc                     func(options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error)
+	DeleteAllDomainMessagesWithContextFunc          func(ctx context.Context, options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error)
+	DeleteAllInboxMessagesFunc                      func(options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error)
+	DeleteAllInboxMessagesWithContextFunc           func(ctx context.Context, options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error)
+	DeleteMessageFunc                               func(options *DeleteMessageOptions) (*DeletedMessages, error)
+	DeleteMessageWithContextFunc                    func(ctx context.Context, options *DeleteMessageOptions) (*DeletedMessages, error)
+	PostMessageFunc                                 func(options *PostMessageOptions) (*PostedMessage, error)
+	PostMessageWithContextFunc                      func(ctx context.Context, options *PostMessageOptions) (*PostedMessage, error)
+	FetchMessageSmtpLogFunc                         func(options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchMessageSmtpLogWithContextFunc              func(ctx context.Context, options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchInboxMessageSmtpLogFunc                    func(options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchInboxMessageSmtpLogWithContextFunc         func(ctx context.Context, options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error)
+	FetchMessageRawFunc                             func(options *FetchMessageRawOptions) (*string, error)
+	FetchMessageRawWithContextFunc                  func(ctx context.Context, options *FetchMessageRawOptions) (*string, error)
+	FetchInboxMessageRawFunc                        func(options *FetchInboxMessageRawOptions) (*string, error)
+	FetchInboxMessageRawWithContextFunc             func(ctx context.Context, options *FetchInboxMessageRawOptions) (*string, error)
+	FetchLatestMessagesFunc                         func(options *FetchLatestMessagesOptions) (*Inbox, error)
+	FetchLatestMessagesWithContextFunc              func(ctx context.Context, options *FetchLatestMessagesOptions) (*Inbox, error)
+	FetchLatestInboxMessagesFunc                    func(options *FetchLatestInboxMessagesOptions) (*Inbox, error)
+	FetchLatestInboxMessagesWithContextFunc         func(ctx context.Context, options *FetchLatestInboxMessagesOptions) (*Inbox, error)
+	StreamInboxMessageAttachmentFunc                func(options *FetchInboxMessageAttachmentOptions) (*Download, error)
+	StreamInboxMessageAttachmentWithContextFunc     func(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*Download, error)
+	StreamMessageAttachmentFunc                     func(options *FetchMessageAttachmentOptions) (*Download, error)
+	StreamMessageAttachmentWithContextFunc          func(ctx context.Context, options *FetchMessageAttachmentOptions) (*Download, error)
+	StreamMessageRawFunc                            func(options *FetchMessageRawOptions) (*Download, error)
+	StreamMessageRawWithContextFunc                 func(ctx context.Context, options *FetchMessageRawOptions) (*Download, error)
+	StreamInboxMessageRawFunc                       func(options *FetchInboxMessageRawOptions) (*Download, error)
+	StreamInboxMessageRawWithContextFunc            func(ctx context.Context, options *FetchInboxMessageRawOptions) (*Download, error)
+	DownloadMessageAttachmentFunc                   func(options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadMessageAttachmentWithContextFunc        func(ctx context.Context, options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageAttachmentFunc              func(options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageAttachmentWithContextFunc   func(ctx context.Context, options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error)
+	DownloadMessageRawFunc                          func(options *FetchMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadMessageRawWithContextFunc               func(ctx context.Context, options *FetchMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageRawFunc                     func(options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error)
+	DownloadInboxMessageRawWithContextFunc          func(ctx context.Context, options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error)
+	GetDomainsFunc                                  func() (*DomainsList, error)
+	GetDomainsWithContextFunc                       func(ctx context.Context) (*DomainsList, error)
+	GetDomainFunc                                   func(options *GetDomainOptions) (*Domain, error)
+	GetDomainWithContextFunc                        func(ctx context.Context, options *GetDomainOptions) (*Domain, error)
+	CreateDomainFunc                                func(options *CreateDomainOptions) (*ResponseStatus, error)
+	CreateDomainWithContextFunc                     func(ctx context.Context, options *CreateDomainOptions) (*ResponseStatus, error)
+	DeleteDomainFunc                                func(options *DeleteDomainOptions) (*ResponseStatus, error)
+	DeleteDomainWithContextFunc                     func(ctx context.Context, options *DeleteDomainOptions) (*ResponseStatus, error)
+	CreateRuleFunc                                  func(options *CreateRuleOptions) (*Rule, error)
+	CreateRuleWithContextFunc                       func(ctx context.Context, options *CreateRuleOptions) (*Rule, error)
+	EnableRuleFunc                                  func(options *EnableRuleOptions) (*ResponseStatus, error)
+	EnableRuleWithContextFunc                       func(ctx context.Context, options *EnableRuleOptions) (*ResponseStatus, error)
+	DisableRuleFunc                                 func(options *DisableRuleOptions) (*ResponseStatus, error)
+	DisableRuleWithContextFunc                      func(ctx context.Context, options *DisableRuleOptions) (*ResponseStatus, error)
+	GetAllRulesFunc                                 func(options *GetAllRulesOptions) (*Rules, error)
+	GetAllRulesWithContextFunc                      func(ctx context.Context, options *GetAllRulesOptions) (*Rules, error)
+	GetRuleFunc                                     func(options *GetRuleOptions) (*Rule, error)
+	GetRuleWithContextFunc                          func(ctx context.Context, options *GetRuleOptions) (*Rule, error)
+	DeleteRuleFunc                                  func(options *DeleteRuleOptions) (*ResponseStatus, error)
+	DeleteRuleWithContextFunc                       func(ctx context.Context, options *DeleteRuleOptions) (*ResponseStatus, error)
+	GetTeamStatsFunc                                func() (*TeamStats, error)
+	GetTeamStatsWithContextFunc                     func(ctx context.Context) (*TeamStats, error)
+	GetTeamFunc                                     func() (*TeamInfo, error)
+	GetTeamWithContextFunc                          func(ctx context.Context) (*TeamInfo, error)
+	GetTeamInfoFunc                                 func() (*TeamInfoData, error)
+	GetTeamInfoWithContextFunc                      func(ctx context.Context) (*TeamInfoData, error)
+	InstantTOTP2FACodeFunc                          func(options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error)
+	InstantTOTP2FACodeWithContextFunc               func(ctx context.Context, options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error)
+	GetAuthenticatorsFunc                           func() (*Authenticators, error)
+	GetAuthenticatorsWithContextFunc                func(ctx context.Context) (*Authenticators, error)
+	GetAuthenticatorsByIdFunc                       func(options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticatorsByIdWithContextFunc            func(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticatorFunc                            func() (*Authenticators, error)
+	GetAuthenticatorWithContextFunc                 func(ctx context.Context) (*Authenticators, error)
+	GetAuthenticatorByIdFunc                        func(options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	GetAuthenticatorByIdWithContextFunc             func(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error)
+	PrivateWebhookFunc                              func(options *PrivateWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateWebhookWithContextFunc                   func(ctx context.Context, options *PrivateWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateInboxWebhookFunc                         func(options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateInboxWebhookWithContextFunc              func(ctx context.Context, options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error)
+	PrivateCustomServiceWebhookFunc                 func(options *PrivateCustomServiceWebhookOptions) error
+	PrivateCustomServiceWebhookWithContextFunc      func(ctx context.Context, options *PrivateCustomServiceWebhookOptions) error
+	PrivateCustomServiceInboxWebhookFunc            func(options *PrivateCustomServiceInboxWebhookOptions) error
+	PrivateCustomServiceInboxWebhookWithContextFunc func(ctx context.Context, options *PrivateCustomServiceInboxWebhookOptions) error
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+var _ API = (*Mock)(nil)
+
+// MockCall is a call recorded by a Mock.
+type MockCall struct {
+	// Method is the name of the method called.
+	Method string
+	// Args holds the arguments of the call.
+	Args []interface{}
+}
+
+// Calls returns the calls made so far, in order.
+func (m *Mock) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the calls made so far to method, in order.
+func (m *Mock) CallsTo(method string) []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []MockCall
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets the calls made so far.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// FetchInbox calls FetchInboxFunc and records the call.
+func (m *Mock) FetchInbox(options *FetchInboxOptions) (*Inbox, error) {
+	m.record("FetchInbox", options)
+	if m.FetchInboxFunc != nil {
+		return m.FetchInboxFunc(options)
+	}
+	if m.FetchInboxWithContextFunc != nil {
+		return m.FetchInboxWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInbox")
+}
+
+// FetchInboxWithContext calls FetchInboxWithContextFunc and records the call.
+func (m *Mock) FetchInboxWithContext(ctx context.Context, options *FetchInboxOptions) (*Inbox, error) {
+	m.record("FetchInboxWithContext", ctx, options)
+	if m.FetchInboxWithContextFunc != nil {
+		return m.FetchInboxWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxWithContext")
+}
+
+// FetchInboxMessage calls FetchInboxMessageFunc and records the call.
+func (m *Mock) FetchInboxMessage(options *FetchInboxMessageOptions) (*Message, error) {
+	m.record("FetchInboxMessage", options)
+	if m.FetchInboxMessageFunc != nil {
+		return m.FetchInboxMessageFunc(options)
+	}
+	if m.FetchInboxMessageWithContextFunc != nil {
+		return m.FetchInboxMessageWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessage")
+}
+
+// FetchInboxMessageWithContext calls FetchInboxMessageWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageWithContext(ctx context.Context, options *FetchInboxMessageOptions) (*Message, error) {
+	m.record("FetchInboxMessageWithContext", ctx, options)
+	if m.FetchInboxMessageWithContextFunc != nil {
+		return m.FetchInboxMessageWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageWithContext")
+}
+
+// FetchMessage calls FetchMessageFunc and records the call.
+func (m *Mock) FetchMessage(options *FetchMessageOptions) (*Message, error) {
+	m.record("FetchMessage", options)
+	if m.FetchMessageFunc != nil {
+		return m.FetchMessageFunc(options)
+	}
+	if m.FetchMessageWithContextFunc != nil {
+		return m.FetchMessageWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessage")
+}
+
+// FetchMessageWithContext calls FetchMessageWithContextFunc and records the call.
+func (m *Mock) FetchMessageWithContext(ctx context.Context, options *FetchMessageOptions) (*Message, error) {
+	m.record("FetchMessageWithContext", ctx, options)
+	if m.FetchMessageWithContextFunc != nil {
+		return m.FetchMessageWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageWithContext")
+}
+
+// FetchSMSMessage calls FetchSMSMessageFunc and records the call.
+func (m *Mock) FetchSMSMessage(options *FetchSMSMessageOptions) (*SMSMessage, error) {
+	m.record("FetchSMSMessage", options)
+	if m.FetchSMSMessageFunc != nil {
+		return m.FetchSMSMessageFunc(options)
+	}
+	if m.FetchSMSMessageWithContextFunc != nil {
+		return m.FetchSMSMessageWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchSMSMessage")
+}
+
+// FetchSMSMessageWithContext calls FetchSMSMessageWithContextFunc and records the call.
+func (m *Mock) FetchSMSMessageWithContext(ctx context.Context, options *FetchSMSMessageOptions) (*SMSMessage, error) {
+	m.record("FetchSMSMessageWithContext", ctx, options)
+	if m.FetchSMSMessageWithContextFunc != nil {
+		return m.FetchSMSMessageWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchSMSMessageWithContext")
+}
+
+// FetchInboxMessageAtachments calls FetchInboxMessageAtachmentsFunc and records the call.
+func (m *Mock) FetchInboxMessageAtachments(options *FetchInboxMessageAttachmentsOptions) (*Attachments, error) {
+	m.record("FetchInboxMessageAtachments", options)
+	if m.FetchInboxMessageAtachmentsFunc != nil {
+		return m.FetchInboxMessageAtachmentsFunc(options)
+	}
+	if m.FetchInboxMessageAtachmentsWithContextFunc != nil {
+		return m.FetchInboxMessageAtachmentsWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessageAtachments")
+}
+
+// FetchInboxMessageAtachmentsWithContext calls FetchInboxMessageAtachmentsWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageAtachmentsWithContext(ctx context.Context, options *FetchInboxMessageAttachmentsOptions) (*Attachments, error) {
+	m.record("FetchInboxMessageAtachmentsWithContext", ctx, options)
+	if m.FetchInboxMessageAtachmentsWithContextFunc != nil {
+		return m.FetchInboxMessageAtachmentsWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageAtachmentsWithContext")
+}
+
+// FetchMessageAtachments calls FetchMessageAtachmentsFunc and records the call.
+func (m *Mock) FetchMessageAtachments(options *FetchMessageAttachmentsOptions) (*Attachments, error) {
+	m.record("FetchMessageAtachments", options)
+	if m.FetchMessageAtachmentsFunc != nil {
+		return m.FetchMessageAtachmentsFunc(options)
+	}
+	if m.FetchMessageAtachmentsWithContextFunc != nil {
+		return m.FetchMessageAtachmentsWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageAtachments")
+}
+
+// FetchMessageAtachmentsWithContext calls FetchMessageAtachmentsWithContextFunc and records the call.
+func (m *Mock) FetchMessageAtachmentsWithContext(ctx context.Context, options *FetchMessageAttachmentsOptions) (*Attachments, error) {
+	m.record("FetchMessageAtachmentsWithContext", ctx, options)
+	if m.FetchMessageAtachmentsWithContextFunc != nil {
+		return m.FetchMessageAtachmentsWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageAtachmentsWithContext")
+}
+
+// FetchInboxMessageAttachment calls FetchInboxMessageAttachmentFunc and records the call.
+func (m *Mock) FetchInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	m.record("FetchInboxMessageAttachment", options)
+	if m.FetchInboxMessageAttachmentFunc != nil {
+		return m.FetchInboxMessageAttachmentFunc(options)
+	}
+	if m.FetchInboxMessageAttachmentWithContextFunc != nil {
+		return m.FetchInboxMessageAttachmentWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessageAttachment")
+}
+
+// FetchInboxMessageAttachmentWithContext calls FetchInboxMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	m.record("FetchInboxMessageAttachmentWithContext", ctx, options)
+	if m.FetchInboxMessageAttachmentWithContextFunc != nil {
+		return m.FetchInboxMessageAttachmentWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageAttachmentWithContext")
+}
+
+// FetchMessageAttachment calls FetchMessageAttachmentFunc and records the call.
+func (m *Mock) FetchMessageAttachment(options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	m.record("FetchMessageAttachment", options)
+	if m.FetchMessageAttachmentFunc != nil {
+		return m.FetchMessageAttachmentFunc(options)
+	}
+	if m.FetchMessageAttachmentWithContextFunc != nil {
+		return m.FetchMessageAttachmentWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageAttachment")
+}
+
+// FetchMessageAttachmentWithContext calls FetchMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) FetchMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*FetchAttachmentResponse, error) {
+	m.record("FetchMessageAttachmentWithContext", ctx, options)
+	if m.FetchMessageAttachmentWithContextFunc != nil {
+		return m.FetchMessageAttachmentWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageAttachmentWithContext")
+}
+
+// FetchMessageLinks calls FetchMessageLinksFunc and records the call.
+func (m *Mock) FetchMessageLinks(options *FetchMessageLinksOptions) (*MessageLinks, error) {
+	m.record("FetchMessageLinks", options)
+	if m.FetchMessageLinksFunc != nil {
+		return m.FetchMessageLinksFunc(options)
+	}
+	if m.FetchMessageLinksWithContextFunc != nil {
+		return m.FetchMessageLinksWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageLinks")
+}
+
+// FetchMessageLinksWithContext calls FetchMessageLinksWithContextFunc and records the call.
+func (m *Mock) FetchMessageLinksWithContext(ctx context.Context, options *FetchMessageLinksOptions) (*MessageLinks, error) {
+	m.record("FetchMessageLinksWithContext", ctx, options)
+	if m.FetchMessageLinksWithContextFunc != nil {
+		return m.FetchMessageLinksWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageLinksWithContext")
+}
+
+// FetchMessageLinksFull calls FetchMessageLinksFullFunc and records the call.
+func (m *Mock) FetchMessageLinksFull(options *FetchMessageLinksFullOptions) (*MessageLinksFull, error) {
+	m.record("FetchMessageLinksFull", options)
+	if m.FetchMessageLinksFullFunc != nil {
+		return m.FetchMessageLinksFullFunc(options)
+	}
+	if m.FetchMessageLinksFullWithContextFunc != nil {
+		return m.FetchMessageLinksFullWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageLinksFull")
+}
+
+// FetchMessageLinksFullWithContext calls FetchMessageLinksFullWithContextFunc and records the call.
+func (m *Mock) FetchMessageLinksFullWithContext(ctx context.Context, options *FetchMessageLinksFullOptions) (*MessageLinksFull, error) {
+	m.record("FetchMessageLinksFullWithContext", ctx, options)
+	if m.FetchMessageLinksFullWithContextFunc != nil {
+		return m.FetchMessageLinksFullWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageLinksFullWithContext")
+}
+
+// FetchInboxMessageLinks calls FetchInboxMessageLinksFunc and records the call.
+func (m *Mock) FetchInboxMessageLinks(options *FetchInboxMessageLinksOptions) (*MessageLinks, error) {
+	m.record("FetchInboxMessageLinks", options)
+	if m.FetchInboxMessageLinksFunc != nil {
+		return m.FetchInboxMessageLinksFunc(options)
+	}
+	if m.FetchInboxMessageLinksWithContextFunc != nil {
+		return m.FetchInboxMessageLinksWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessageLinks")
+}
+
+// FetchInboxMessageLinksWithContext calls FetchInboxMessageLinksWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageLinksWithContext(ctx context.Context, options *FetchInboxMessageLinksOptions) (*MessageLinks, error) {
+	m.record("FetchInboxMessageLinksWithContext", ctx, options)
+	if m.FetchInboxMessageLinksWithContextFunc != nil {
+		return m.FetchInboxMessageLinksWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageLinksWithContext")
+}
+
+// DeleteAllDomainMessages calls DeleteAllDomainMessagesFunc and records the call.
+func (m *Mock) DeleteAllDomainMessages(options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error) {
+	m.record("DeleteAllDomainMessages", options)
+	if m.DeleteAllDomainMessagesFunc != nil {
+		return m.DeleteAllDomainMessagesFunc(options)
+	}
+	if m.DeleteAllDomainMessagesWithContextFunc != nil {
+		return m.DeleteAllDomainMessagesWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DeleteAllDomainMessages")
+}
+
+// DeleteAllDomainMessagesWithContext calls DeleteAllDomainMessagesWithContextFunc and records the call.
+func (m *Mock) DeleteAllDomainMessagesWithContext(ctx context.Context, options *DeleteAllDomainMessagesOptions) (*DeletedMessages, error) {
+	m.record("DeleteAllDomainMessagesWithContext", ctx, options)
+	if m.DeleteAllDomainMessagesWithContextFunc != nil {
+		return m.DeleteAllDomainMessagesWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DeleteAllDomainMessagesWithContext")
+}
+
+// DeleteAllInboxMessages calls DeleteAllInboxMessagesFunc and records the call.
+func (m *Mock) DeleteAllInboxMessages(options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error) {
+	m.record("DeleteAllInboxMessages", options)
+	if m.DeleteAllInboxMessagesFunc != nil {
+		return m.DeleteAllInboxMessagesFunc(options)
+	}
+	if m.DeleteAllInboxMessagesWithContextFunc != nil {
+		return m.DeleteAllInboxMessagesWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DeleteAllInboxMessages")
+}
+
+// DeleteAllInboxMessagesWithContext calls DeleteAllInboxMessagesWithContextFunc and records the call.
+func (m *Mock) DeleteAllInboxMessagesWithContext(ctx context.Context, options *DeleteAllInboxMessagesOptions) (*DeletedMessages, error) {
+	m.record("DeleteAllInboxMessagesWithContext", ctx, options)
+	if m.DeleteAllInboxMessagesWithContextFunc != nil {
+		return m.DeleteAllInboxMessagesWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DeleteAllInboxMessagesWithContext")
+}
+
+// DeleteMessage calls DeleteMessageFunc and records the call.
+func (m *Mock) DeleteMessage(options *DeleteMessageOptions) (*DeletedMessages, error) {
+	m.record("DeleteMessage", options)
+	if m.DeleteMessageFunc != nil {
+		return m.DeleteMessageFunc(options)
+	}
+	if m.DeleteMessageWithContextFunc != nil {
+		return m.DeleteMessageWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DeleteMessage")
+}
+
+// DeleteMessageWithContext calls DeleteMessageWithContextFunc and records the call.
+func (m *Mock) DeleteMessageWithContext(ctx context.Context, options *DeleteMessageOptions) (*DeletedMessages, error) {
+	m.record("DeleteMessageWithContext", ctx, options)
+	if m.DeleteMessageWithContextFunc != nil {
+		return m.DeleteMessageWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DeleteMessageWithContext")
+}
+
+// PostMessage calls PostMessageFunc and records the call.
+func (m *Mock) PostMessage(options *PostMessageOptions) (*PostedMessage, error) {
+	m.record("PostMessage", options)
+	if m.PostMessageFunc != nil {
+		return m.PostMessageFunc(options)
+	}
+	if m.PostMessageWithContextFunc != nil {
+		return m.PostMessageWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("PostMessage")
+}
+
+// PostMessageWithContext calls PostMessageWithContextFunc and records the call.
+func (m *Mock) PostMessageWithContext(ctx context.Context, options *PostMessageOptions) (*PostedMessage, error) {
+	m.record("PostMessageWithContext", ctx, options)
+	if m.PostMessageWithContextFunc != nil {
+		return m.PostMessageWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("PostMessageWithContext")
+}
+
+// FetchMessageSmtpLog calls FetchMessageSmtpLogFunc and records the call.
+func (m *Mock) FetchMessageSmtpLog(options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	m.record("FetchMessageSmtpLog", options)
+	if m.FetchMessageSmtpLogFunc != nil {
+		return m.FetchMessageSmtpLogFunc(options)
+	}
+	if m.FetchMessageSmtpLogWithContextFunc != nil {
+		return m.FetchMessageSmtpLogWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageSmtpLog")
+}
+
+// FetchMessageSmtpLogWithContext calls FetchMessageSmtpLogWithContextFunc and records the call.
+func (m *Mock) FetchMessageSmtpLogWithContext(ctx context.Context, options *FetchMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	m.record("FetchMessageSmtpLogWithContext", ctx, options)
+	if m.FetchMessageSmtpLogWithContextFunc != nil {
+		return m.FetchMessageSmtpLogWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageSmtpLogWithContext")
+}
+
+// FetchInboxMessageSmtpLog calls FetchInboxMessageSmtpLogFunc and records the call.
+func (m *Mock) FetchInboxMessageSmtpLog(options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	m.record("FetchInboxMessageSmtpLog", options)
+	if m.FetchInboxMessageSmtpLogFunc != nil {
+		return m.FetchInboxMessageSmtpLogFunc(options)
+	}
+	if m.FetchInboxMessageSmtpLogWithContextFunc != nil {
+		return m.FetchInboxMessageSmtpLogWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessageSmtpLog")
+}
+
+// FetchInboxMessageSmtpLogWithContext calls FetchInboxMessageSmtpLogWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageSmtpLogWithContext(ctx context.Context, options *FetchInboxMessageSmtpLogOptions) (*MessageSmtpLogs, error) {
+	m.record("FetchInboxMessageSmtpLogWithContext", ctx, options)
+	if m.FetchInboxMessageSmtpLogWithContextFunc != nil {
+		return m.FetchInboxMessageSmtpLogWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageSmtpLogWithContext")
+}
+
+// FetchMessageRaw calls FetchMessageRawFunc and records the call.
+func (m *Mock) FetchMessageRaw(options *FetchMessageRawOptions) (*string, error) {
+	m.record("FetchMessageRaw", options)
+	if m.FetchMessageRawFunc != nil {
+		return m.FetchMessageRawFunc(options)
+	}
+	if m.FetchMessageRawWithContextFunc != nil {
+		return m.FetchMessageRawWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchMessageRaw")
+}
+
+// FetchMessageRawWithContext calls FetchMessageRawWithContextFunc and records the call.
+func (m *Mock) FetchMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*string, error) {
+	m.record("FetchMessageRawWithContext", ctx, options)
+	if m.FetchMessageRawWithContextFunc != nil {
+		return m.FetchMessageRawWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchMessageRawWithContext")
+}
+
+// FetchInboxMessageRaw calls FetchInboxMessageRawFunc and records the call.
+func (m *Mock) FetchInboxMessageRaw(options *FetchInboxMessageRawOptions) (*string, error) {
+	m.record("FetchInboxMessageRaw", options)
+	if m.FetchInboxMessageRawFunc != nil {
+		return m.FetchInboxMessageRawFunc(options)
+	}
+	if m.FetchInboxMessageRawWithContextFunc != nil {
+		return m.FetchInboxMessageRawWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchInboxMessageRaw")
+}
+
+// FetchInboxMessageRawWithContext calls FetchInboxMessageRawWithContextFunc and records the call.
+func (m *Mock) FetchInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*string, error) {
+	m.record("FetchInboxMessageRawWithContext", ctx, options)
+	if m.FetchInboxMessageRawWithContextFunc != nil {
+		return m.FetchInboxMessageRawWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchInboxMessageRawWithContext")
+}
+
+// FetchLatestMessages calls FetchLatestMessagesFunc and records the call.
+func (m *Mock) FetchLatestMessages(options *FetchLatestMessagesOptions) (*Inbox, error) {
+	m.record("FetchLatestMessages", options)
+	if m.FetchLatestMessagesFunc != nil {
+		return m.FetchLatestMessagesFunc(options)
+	}
+	if m.FetchLatestMessagesWithContextFunc != nil {
+		return m.FetchLatestMessagesWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchLatestMessages")
+}
+
+// FetchLatestMessagesWithContext calls FetchLatestMessagesWithContextFunc and records the call.
+func (m *Mock) FetchLatestMessagesWithContext(ctx context.Context, options *FetchLatestMessagesOptions) (*Inbox, error) {
+	m.record("FetchLatestMessagesWithContext", ctx, options)
+	if m.FetchLatestMessagesWithContextFunc != nil {
+		return m.FetchLatestMessagesWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchLatestMessagesWithContext")
+}
+
+// FetchLatestInboxMessages calls FetchLatestInboxMessagesFunc and records the call.
+func (m *Mock) FetchLatestInboxMessages(options *FetchLatestInboxMessagesOptions) (*Inbox, error) {
+	m.record("FetchLatestInboxMessages", options)
+	if m.FetchLatestInboxMessagesFunc != nil {
+		return m.FetchLatestInboxMessagesFunc(options)
+	}
+	if m.FetchLatestInboxMessagesWithContextFunc != nil {
+		return m.FetchLatestInboxMessagesWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("FetchLatestInboxMessages")
+}
+
+// FetchLatestInboxMessagesWithContext calls FetchLatestInboxMessagesWithContextFunc and records the call.
+func (m *Mock) FetchLatestInboxMessagesWithContext(ctx context.Context, options *FetchLatestInboxMessagesOptions) (*Inbox, error) {
+	m.record("FetchLatestInboxMessagesWithContext", ctx, options)
+	if m.FetchLatestInboxMessagesWithContextFunc != nil {
+		return m.FetchLatestInboxMessagesWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("FetchLatestInboxMessagesWithContext")
+}
+
+// StreamInboxMessageAttachment calls StreamInboxMessageAttachmentFunc and records the call.
+func (m *Mock) StreamInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*Download, error) {
+	m.record("StreamInboxMessageAttachment", options)
+	if m.StreamInboxMessageAttachmentFunc != nil {
+		return m.StreamInboxMessageAttachmentFunc(options)
+	}
+	if m.StreamInboxMessageAttachmentWithContextFunc != nil {
+		return m.StreamInboxMessageAttachmentWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("StreamInboxMessageAttachment")
+}
+
+// StreamInboxMessageAttachmentWithContext calls StreamInboxMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) StreamInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*Download, error) {
+	m.record("StreamInboxMessageAttachmentWithContext", ctx, options)
+	if m.StreamInboxMessageAttachmentWithContextFunc != nil {
+		return m.StreamInboxMessageAttachmentWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("StreamInboxMessageAttachmentWithContext")
+}
+
+// StreamMessageAttachment calls StreamMessageAttachmentFunc and records the call.
+func (m *Mock) StreamMessageAttachment(options *FetchMessageAttachmentOptions) (*Download, error) {
+	m.record("StreamMessageAttachment", options)
+	if m.StreamMessageAttachmentFunc != nil {
+		return m.StreamMessageAttachmentFunc(options)
+	}
+	if m.StreamMessageAttachmentWithContextFunc != nil {
+		return m.StreamMessageAttachmentWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("StreamMessageAttachment")
+}
+
+// StreamMessageAttachmentWithContext calls StreamMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) StreamMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*Download, error) {
+	m.record("StreamMessageAttachmentWithContext", ctx, options)
+	if m.StreamMessageAttachmentWithContextFunc != nil {
+		return m.StreamMessageAttachmentWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("StreamMessageAttachmentWithContext")
+}
+
+// StreamMessageRaw calls StreamMessageRawFunc and records the call.
+func (m *Mock) StreamMessageRaw(options *FetchMessageRawOptions) (*Download, error) {
+	m.record("StreamMessageRaw", options)
+	if m.StreamMessageRawFunc != nil {
+		return m.StreamMessageRawFunc(options)
+	}
+	if m.StreamMessageRawWithContextFunc != nil {
+		return m.StreamMessageRawWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("StreamMessageRaw")
+}
+
+// StreamMessageRawWithContext calls StreamMessageRawWithContextFunc and records the call.
+func (m *Mock) StreamMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*Download, error) {
+	m.record("StreamMessageRawWithContext", ctx, options)
+	if m.StreamMessageRawWithContextFunc != nil {
+		return m.StreamMessageRawWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("StreamMessageRawWithContext")
+}
+
+// StreamInboxMessageRaw calls StreamInboxMessageRawFunc and records the call.
+func (m *Mock) StreamInboxMessageRaw(options *FetchInboxMessageRawOptions) (*Download, error) {
+	m.record("StreamInboxMessageRaw", options)
+	if m.StreamInboxMessageRawFunc != nil {
+		return m.StreamInboxMessageRawFunc(options)
+	}
+	if m.StreamInboxMessageRawWithContextFunc != nil {
+		return m.StreamInboxMessageRawWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("StreamInboxMessageRaw")
+}
+
+// StreamInboxMessageRawWithContext calls StreamInboxMessageRawWithContextFunc and records the call.
+func (m *Mock) StreamInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*Download, error) {
+	m.record("StreamInboxMessageRawWithContext", ctx, options)
+	if m.StreamInboxMessageRawWithContextFunc != nil {
+		return m.StreamInboxMessageRawWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("StreamInboxMessageRawWithContext")
+}
+
+// DownloadMessageAttachment calls DownloadMessageAttachmentFunc and records the call.
+func (m *Mock) DownloadMessageAttachment(options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadMessageAttachment", options, w)
+	if m.DownloadMessageAttachmentFunc != nil {
+		return m.DownloadMessageAttachmentFunc(options, w)
+	}
+	if m.DownloadMessageAttachmentWithContextFunc != nil {
+		return m.DownloadMessageAttachmentWithContextFunc(context.Background(), options, w)
+	}
+	return nil, notStubbed("DownloadMessageAttachment")
+}
+
+// DownloadMessageAttachmentWithContext calls DownloadMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) DownloadMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadMessageAttachmentWithContext", ctx, options, w)
+	if m.DownloadMessageAttachmentWithContextFunc != nil {
+		return m.DownloadMessageAttachmentWithContextFunc(ctx, options, w)
+	}
+	return nil, notStubbed("DownloadMessageAttachmentWithContext")
+}
+
+// DownloadInboxMessageAttachment calls DownloadInboxMessageAttachmentFunc and records the call.
+func (m *Mock) DownloadInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadInboxMessageAttachment", options, w)
+	if m.DownloadInboxMessageAttachmentFunc != nil {
+		return m.DownloadInboxMessageAttachmentFunc(options, w)
+	}
+	if m.DownloadInboxMessageAttachmentWithContextFunc != nil {
+		return m.DownloadInboxMessageAttachmentWithContextFunc(context.Background(), options, w)
+	}
+	return nil, notStubbed("DownloadInboxMessageAttachment")
+}
+
+// DownloadInboxMessageAttachmentWithContext calls DownloadInboxMessageAttachmentWithContextFunc and records the call.
+func (m *Mock) DownloadInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadInboxMessageAttachmentWithContext", ctx, options, w)
+	if m.DownloadInboxMessageAttachmentWithContextFunc != nil {
+		return m.DownloadInboxMessageAttachmentWithContextFunc(ctx, options, w)
+	}
+	return nil, notStubbed("DownloadInboxMessageAttachmentWithContext")
+}
+
+// DownloadMessageRaw calls DownloadMessageRawFunc and records the call.
+func (m *Mock) DownloadMessageRaw(options *FetchMessageRawOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadMessageRaw", options, w)
+	if m.DownloadMessageRawFunc != nil {
+		return m.DownloadMessageRawFunc(options, w)
+	}
+	if m.DownloadMessageRawWithContextFunc != nil {
+		return m.DownloadMessageRawWithContextFunc(context.Background(), options, w)
+	}
+	return nil, notStubbed("DownloadMessageRaw")
+}
+
+// DownloadMessageRawWithContext calls DownloadMessageRawWithContextFunc and records the call.
+func (m *Mock) DownloadMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadMessageRawWithContext", ctx, options, w)
+	if m.DownloadMessageRawWithContextFunc != nil {
+		return m.DownloadMessageRawWithContextFunc(ctx, options, w)
+	}
+	return nil, notStubbed("DownloadMessageRawWithContext")
+}
+
+// DownloadInboxMessageRaw calls DownloadInboxMessageRawFunc and records the call.
+func (m *Mock) DownloadInboxMessageRaw(options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadInboxMessageRaw", options, w)
+	if m.DownloadInboxMessageRawFunc != nil {
+		return m.DownloadInboxMessageRawFunc(options, w)
+	}
+	if m.DownloadInboxMessageRawWithContextFunc != nil {
+		return m.DownloadInboxMessageRawWithContextFunc(context.Background(), options, w)
+	}
+	return nil, notStubbed("DownloadInboxMessageRaw")
+}
+
+// DownloadInboxMessageRawWithContext calls DownloadInboxMessageRawWithContextFunc and records the call.
+func (m *Mock) DownloadInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error) {
+	m.record("DownloadInboxMessageRawWithContext", ctx, options, w)
+	if m.DownloadInboxMessageRawWithContextFunc != nil {
+		return m.DownloadInboxMessageRawWithContextFunc(ctx, options, w)
+	}
+	return nil, notStubbed("DownloadInboxMessageRawWithContext")
+}
+
+// GetDomains calls GetDomainsFunc and records the call.
+func (m *Mock) GetDomains() (*DomainsList, error) {
+	m.record("GetDomains")
+	if m.GetDomainsFunc != nil {
+		return m.GetDomainsFunc()
+	}
+	if m.GetDomainsWithContextFunc != nil {
+		return m.GetDomainsWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetDomains")
+}
+
+// GetDomainsWithContext calls GetDomainsWithContextFunc and records the call.
+func (m *Mock) GetDomainsWithContext(ctx context.Context) (*DomainsList, error) {
+	m.record("GetDomainsWithContext", ctx)
+	if m.GetDomainsWithContextFunc != nil {
+		return m.GetDomainsWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetDomainsWithContext")
+}
+
+// GetDomain calls GetDomainFunc and records the call.
+func (m *Mock) GetDomain(options *GetDomainOptions) (*Domain, error) {
+	m.record("GetDomain", options)
+	if m.GetDomainFunc != nil {
+		return m.GetDomainFunc(options)
+	}
+	if m.GetDomainWithContextFunc != nil {
+		return m.GetDomainWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("GetDomain")
+}
+
+// GetDomainWithContext calls GetDomainWithContextFunc and records the call.
+func (m *Mock) GetDomainWithContext(ctx context.Context, options *GetDomainOptions) (*Domain, error) {
+	m.record("GetDomainWithContext", ctx, options)
+	if m.GetDomainWithContextFunc != nil {
+		return m.GetDomainWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("GetDomainWithContext")
+}
+
+// CreateDomain calls CreateDomainFunc and records the call.
+func (m *Mock) CreateDomain(options *CreateDomainOptions) (*ResponseStatus, error) {
+	m.record("CreateDomain", options)
+	if m.CreateDomainFunc != nil {
+		return m.CreateDomainFunc(options)
+	}
+	if m.CreateDomainWithContextFunc != nil {
+		return m.CreateDomainWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("CreateDomain")
+}
+
+// CreateDomainWithContext calls CreateDomainWithContextFunc and records the call.
+func (m *Mock) CreateDomainWithContext(ctx context.Context, options *CreateDomainOptions) (*ResponseStatus, error) {
+	m.record("CreateDomainWithContext", ctx, options)
+	if m.CreateDomainWithContextFunc != nil {
+		return m.CreateDomainWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("CreateDomainWithContext")
+}
+
+// DeleteDomain calls DeleteDomainFunc and records the call.
+func (m *Mock) DeleteDomain(options *DeleteDomainOptions) (*ResponseStatus, error) {
+	m.record("DeleteDomain", options)
+	if m.DeleteDomainFunc != nil {
+		return m.DeleteDomainFunc(options)
+	}
+	if m.DeleteDomainWithContextFunc != nil {
+		return m.DeleteDomainWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DeleteDomain")
+}
+
+// DeleteDomainWithContext calls DeleteDomainWithContextFunc and records the call.
+func (m *Mock) DeleteDomainWithContext(ctx context.Context, options *DeleteDomainOptions) (*ResponseStatus, error) {
+	m.record("DeleteDomainWithContext", ctx, options)
+	if m.DeleteDomainWithContextFunc != nil {
+		return m.DeleteDomainWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DeleteDomainWithContext")
+}
+
+// CreateRule calls CreateRuleFunc and records the call.
+func (m *Mock) CreateRule(options *CreateRuleOptions) (*Rule, error) {
+	m.record("CreateRule", options)
+	if m.CreateRuleFunc != nil {
+		return m.CreateRuleFunc(options)
+	}
+	if m.CreateRuleWithContextFunc != nil {
+		return m.CreateRuleWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("CreateRule")
+}
+
+// CreateRuleWithContext calls CreateRuleWithContextFunc and records the call.
+func (m *Mock) CreateRuleWithContext(ctx context.Context, options *CreateRuleOptions) (*Rule, error) {
+	m.record("CreateRuleWithContext", ctx, options)
+	if m.CreateRuleWithContextFunc != nil {
+		return m.CreateRuleWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("CreateRuleWithContext")
+}
+
+// EnableRule calls EnableRuleFunc and records the call.
+func (m *Mock) EnableRule(options *EnableRuleOptions) (*ResponseStatus, error) {
+	m.record("EnableRule", options)
+	if m.EnableRuleFunc != nil {
+		return m.EnableRuleFunc(options)
+	}
+	if m.EnableRuleWithContextFunc != nil {
+		return m.EnableRuleWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("EnableRule")
+}
+
+// EnableRuleWithContext calls EnableRuleWithContextFunc and records the call.
+func (m *Mock) EnableRuleWithContext(ctx context.Context, options *EnableRuleOptions) (*ResponseStatus, error) {
+	m.record("EnableRuleWithContext", ctx, options)
+	if m.EnableRuleWithContextFunc != nil {
+		return m.EnableRuleWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("EnableRuleWithContext")
+}
+
+// DisableRule calls DisableRuleFunc and records the call.
+func (m *Mock) DisableRule(options *DisableRuleOptions) (*ResponseStatus, error) {
+	m.record("DisableRule", options)
+	if m.DisableRuleFunc != nil {
+		return m.DisableRuleFunc(options)
+	}
+	if m.DisableRuleWithContextFunc != nil {
+		return m.DisableRuleWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DisableRule")
+}
+
+// DisableRuleWithContext calls DisableRuleWithContextFunc and records the call.
+func (m *Mock) DisableRuleWithContext(ctx context.Context, options *DisableRuleOptions) (*ResponseStatus, error) {
+	m.record("DisableRuleWithContext", ctx, options)
+	if m.DisableRuleWithContextFunc != nil {
+		return m.DisableRuleWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DisableRuleWithContext")
+}
+
+// GetAllRules calls GetAllRulesFunc and records the call.
+func (m *Mock) GetAllRules(options *GetAllRulesOptions) (*Rules, error) {
+	m.record("GetAllRules", options)
+	if m.GetAllRulesFunc != nil {
+		return m.GetAllRulesFunc(options)
+	}
+	if m.GetAllRulesWithContextFunc != nil {
+		return m.GetAllRulesWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("GetAllRules")
+}
+
+// GetAllRulesWithContext calls GetAllRulesWithContextFunc and records the call.
+func (m *Mock) GetAllRulesWithContext(ctx context.Context, options *GetAllRulesOptions) (*Rules, error) {
+	m.record("GetAllRulesWithContext", ctx, options)
+	if m.GetAllRulesWithContextFunc != nil {
+		return m.GetAllRulesWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("GetAllRulesWithContext")
+}
+
+// GetRule calls GetRuleFunc and records the call.
+func (m *Mock) GetRule(options *GetRuleOptions) (*Rule, error) {
+	m.record("GetRule", options)
+	if m.GetRuleFunc != nil {
+		return m.GetRuleFunc(options)
+	}
+	if m.GetRuleWithContextFunc != nil {
+		return m.GetRuleWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("GetRule")
+}
+
+// GetRuleWithContext calls GetRuleWithContextFunc and records the call.
+func (m *Mock) GetRuleWithContext(ctx context.Context, options *GetRuleOptions) (*Rule, error) {
+	m.record("GetRuleWithContext", ctx, options)
+	if m.GetRuleWithContextFunc != nil {
+		return m.GetRuleWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("GetRuleWithContext")
+}
+
+// DeleteRule calls DeleteRuleFunc and records the call.
+func (m *Mock) DeleteRule(options *DeleteRuleOptions) (*ResponseStatus, error) {
+	m.record("DeleteRule", options)
+	if m.DeleteRuleFunc != nil {
+		return m.DeleteRuleFunc(options)
+	}
+	if m.DeleteRuleWithContextFunc != nil {
+		return m.DeleteRuleWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("DeleteRule")
+}
+
+// DeleteRuleWithContext calls DeleteRuleWithContextFunc and records the call.
+func (m *Mock) DeleteRuleWithContext(ctx context.Context, options *DeleteRuleOptions) (*ResponseStatus, error) {
+	m.record("DeleteRuleWithContext", ctx, options)
+	if m.DeleteRuleWithContextFunc != nil {
+		return m.DeleteRuleWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("DeleteRuleWithContext")
+}
+
+// GetTeamStats calls GetTeamStatsFunc and records the call.
+func (m *Mock) GetTeamStats() (*TeamStats, error) {
+	m.record("GetTeamStats")
+	if m.GetTeamStatsFunc != nil {
+		return m.GetTeamStatsFunc()
+	}
+	if m.GetTeamStatsWithContextFunc != nil {
+		return m.GetTeamStatsWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetTeamStats")
+}
+
+// GetTeamStatsWithContext calls GetTeamStatsWithContextFunc and records the call.
+func (m *Mock) GetTeamStatsWithContext(ctx context.Context) (*TeamStats, error) {
+	m.record("GetTeamStatsWithContext", ctx)
+	if m.GetTeamStatsWithContextFunc != nil {
+		return m.GetTeamStatsWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetTeamStatsWithContext")
+}
+
+// GetTeam calls GetTeamFunc and records the call.
+func (m *Mock) GetTeam() (*TeamInfo, error) {
+	m.record("GetTeam")
+	if m.GetTeamFunc != nil {
+		return m.GetTeamFunc()
+	}
+	if m.GetTeamWithContextFunc != nil {
+		return m.GetTeamWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetTeam")
+}
+
+// GetTeamWithContext calls GetTeamWithContextFunc and records the call.
+func (m *Mock) GetTeamWithContext(ctx context.Context) (*TeamInfo, error) {
+	m.record("GetTeamWithContext", ctx)
+	if m.GetTeamWithContextFunc != nil {
+		return m.GetTeamWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetTeamWithContext")
+}
+
+// GetTeamInfo calls GetTeamInfoFunc and records the call.
+func (m *Mock) GetTeamInfo() (*TeamInfoData, error) {
+	m.record("GetTeamInfo")
+	if m.GetTeamInfoFunc != nil {
+		return m.GetTeamInfoFunc()
+	}
+	if m.GetTeamInfoWithContextFunc != nil {
+		return m.GetTeamInfoWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetTeamInfo")
+}
+
+// GetTeamInfoWithContext calls GetTeamInfoWithContextFunc and records the call.
+func (m *Mock) GetTeamInfoWithContext(ctx context.Context) (*TeamInfoData, error) {
+	m.record("GetTeamInfoWithContext", ctx)
+	if m.GetTeamInfoWithContextFunc != nil {
+		return m.GetTeamInfoWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetTeamInfoWithContext")
+}
+
+// InstantTOTP2FACode calls InstantTOTP2FACodeFunc and records the call.
+func (m *Mock) InstantTOTP2FACode(options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error) {
+	m.record("InstantTOTP2FACode", options)
+	if m.InstantTOTP2FACodeFunc != nil {
+		return m.InstantTOTP2FACodeFunc(options)
+	}
+	if m.InstantTOTP2FACodeWithContextFunc != nil {
+		return m.InstantTOTP2FACodeWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("InstantTOTP2FACode")
+}
+
+// InstantTOTP2FACodeWithContext calls InstantTOTP2FACodeWithContextFunc and records the call.
+func (m *Mock) InstantTOTP2FACodeWithContext(ctx context.Context, options *InstantTOTP2FACodeOptions) (*InstantTOTP2FACode, error) {
+	m.record("InstantTOTP2FACodeWithContext", ctx, options)
+	if m.InstantTOTP2FACodeWithContextFunc != nil {
+		return m.InstantTOTP2FACodeWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("InstantTOTP2FACodeWithContext")
+}
+
+// GetAuthenticators calls GetAuthenticatorsFunc and records the call.
+func (m *Mock) GetAuthenticators() (*Authenticators, error) {
+	m.record("GetAuthenticators")
+	if m.GetAuthenticatorsFunc != nil {
+		return m.GetAuthenticatorsFunc()
+	}
+	if m.GetAuthenticatorsWithContextFunc != nil {
+		return m.GetAuthenticatorsWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetAuthenticators")
+}
+
+// GetAuthenticatorsWithContext calls GetAuthenticatorsWithContextFunc and records the call.
+func (m *Mock) GetAuthenticatorsWithContext(ctx context.Context) (*Authenticators, error) {
+	m.record("GetAuthenticatorsWithContext", ctx)
+	if m.GetAuthenticatorsWithContextFunc != nil {
+		return m.GetAuthenticatorsWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetAuthenticatorsWithContext")
+}
+
+// GetAuthenticatorsById calls GetAuthenticatorsByIdFunc and records the call.
+func (m *Mock) GetAuthenticatorsById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	m.record("GetAuthenticatorsById", options)
+	if m.GetAuthenticatorsByIdFunc != nil {
+		return m.GetAuthenticatorsByIdFunc(options)
+	}
+	if m.GetAuthenticatorsByIdWithContextFunc != nil {
+		return m.GetAuthenticatorsByIdWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("GetAuthenticatorsById")
+}
+
+// GetAuthenticatorsByIdWithContext calls GetAuthenticatorsByIdWithContextFunc and records the call.
+func (m *Mock) GetAuthenticatorsByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	m.record("GetAuthenticatorsByIdWithContext", ctx, options)
+	if m.GetAuthenticatorsByIdWithContextFunc != nil {
+		return m.GetAuthenticatorsByIdWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("GetAuthenticatorsByIdWithContext")
+}
+
+// GetAuthenticator calls GetAuthenticatorFunc and records the call.
+func (m *Mock) GetAuthenticator() (*Authenticators, error) {
+	m.record("GetAuthenticator")
+	if m.GetAuthenticatorFunc != nil {
+		return m.GetAuthenticatorFunc()
+	}
+	if m.GetAuthenticatorWithContextFunc != nil {
+		return m.GetAuthenticatorWithContextFunc(context.Background())
+	}
+	return nil, notStubbed("GetAuthenticator")
+}
+
+// GetAuthenticatorWithContext calls GetAuthenticatorWithContextFunc and records the call.
+func (m *Mock) GetAuthenticatorWithContext(ctx context.Context) (*Authenticators, error) {
+	m.record("GetAuthenticatorWithContext", ctx)
+	if m.GetAuthenticatorWithContextFunc != nil {
+		return m.GetAuthenticatorWithContextFunc(ctx)
+	}
+	return nil, notStubbed("GetAuthenticatorWithContext")
+}
+
+// GetAuthenticatorById calls GetAuthenticatorByIdFunc and records the call.
+func (m *Mock) GetAuthenticatorById(options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	m.record("GetAuthenticatorById", options)
+	if m.GetAuthenticatorByIdFunc != nil {
+		return m.GetAuthenticatorByIdFunc(options)
+	}
+	if m.GetAuthenticatorByIdWithContextFunc != nil {
+		return m.GetAuthenticatorByIdWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("GetAuthenticatorById")
+}
+
+// GetAuthenticatorByIdWithContext calls GetAuthenticatorByIdWithContextFunc and records the call.
+func (m *Mock) GetAuthenticatorByIdWithContext(ctx context.Context, options *GetAuthenticatorsByIdOptions) (*Authenticator, error) {
+	m.record("GetAuthenticatorByIdWithContext", ctx, options)
+	if m.GetAuthenticatorByIdWithContextFunc != nil {
+		return m.GetAuthenticatorByIdWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("GetAuthenticatorByIdWithContext")
+}
+
+// PrivateWebhook calls PrivateWebhookFunc and records the call.
+func (m *Mock) PrivateWebhook(options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	m.record("PrivateWebhook", options)
+	if m.PrivateWebhookFunc != nil {
+		return m.PrivateWebhookFunc(options)
+	}
+	if m.PrivateWebhookWithContextFunc != nil {
+		return m.PrivateWebhookWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("PrivateWebhook")
+}
+
+// PrivateWebhookWithContext calls PrivateWebhookWithContextFunc and records the call.
+func (m *Mock) PrivateWebhookWithContext(ctx context.Context, options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	m.record("PrivateWebhookWithContext", ctx, options)
+	if m.PrivateWebhookWithContextFunc != nil {
+		return m.PrivateWebhookWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("PrivateWebhookWithContext")
+}
+
+// PrivateInboxWebhook calls PrivateInboxWebhookFunc and records the call.
+func (m *Mock) PrivateInboxWebhook(options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	m.record("PrivateInboxWebhook", options)
+	if m.PrivateInboxWebhookFunc != nil {
+		return m.PrivateInboxWebhookFunc(options)
+	}
+	if m.PrivateInboxWebhookWithContextFunc != nil {
+		return m.PrivateInboxWebhookWithContextFunc(context.Background(), options)
+	}
+	return nil, notStubbed("PrivateInboxWebhook")
+}
+
+// PrivateInboxWebhookWithContext calls PrivateInboxWebhookWithContextFunc and records the call.
+func (m *Mock) PrivateInboxWebhookWithContext(ctx context.Context, options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	m.record("PrivateInboxWebhookWithContext", ctx, options)
+	if m.PrivateInboxWebhookWithContextFunc != nil {
+		return m.PrivateInboxWebhookWithContextFunc(ctx, options)
+	}
+	return nil, notStubbed("PrivateInboxWebhookWithContext")
+}
+
+// PrivateCustomServiceWebhook calls PrivateCustomServiceWebhookFunc and records the call.
+func (m *Mock) PrivateCustomServiceWebhook(options *PrivateCustomServiceWebhookOptions) error {
+	m.record("PrivateCustomServiceWebhook", options)
+	if m.PrivateCustomServiceWebhookFunc != nil {
+		return m.PrivateCustomServiceWebhookFunc(options)
+	}
+	if m.PrivateCustomServiceWebhookWithContextFunc != nil {
+		return m.PrivateCustomServiceWebhookWithContextFunc(context.Background(), options)
+	}
+	return notStubbed("PrivateCustomServiceWebhook")
+}
+
+// PrivateCustomServiceWebhookWithContext calls PrivateCustomServiceWebhookWithContextFunc and records the call.
+func (m *Mock) PrivateCustomServiceWebhookWithContext(ctx context.Context, options *PrivateCustomServiceWebhookOptions) error {
+	m.record("PrivateCustomServiceWebhookWithContext", ctx, options)
+	if m.PrivateCustomServiceWebhookWithContextFunc != nil {
+		return m.PrivateCustomServiceWebhookWithContextFunc(ctx, options)
+	}
+	return notStubbed("PrivateCustomServiceWebhookWithContext")
+}
+
+// PrivateCustomServiceInboxWebhook calls PrivateCustomServiceInboxWebhookFunc and records the call.
+func (m *Mock) PrivateCustomServiceInboxWebhook(options *PrivateCustomServiceInboxWebhookOptions) error {
+	m.record("PrivateCustomServiceInboxWebhook", options)
+	if m.PrivateCustomServiceInboxWebhookFunc != nil {
+		return m.PrivateCustomServiceInboxWebhookFunc(options)
+	}
+	if m.PrivateCustomServiceInboxWebhookWithContextFunc != nil {
+		return m.PrivateCustomServiceInboxWebhookWithContextFunc(context.Background(), options)
+	}
+	return notStubbed("PrivateCustomServiceInboxWebhook")
+}
+
+// PrivateCustomServiceInboxWebhookWithContext calls PrivateCustomServiceInboxWebhookWithContextFunc and records the call.
+func (m *Mock) PrivateCustomServiceInboxWebhookWithContext(ctx context.Context, options *PrivateCustomServiceInboxWebhookOptions) error {
+	m.record("PrivateCustomServiceInboxWebhookWithContext", ctx, options)
+	if m.PrivateCustomServiceInboxWebhookWithContextFunc != nil {
+		return m.PrivateCustomServiceInboxWebhookWithContextFunc(ctx, options)
+	}
+	return notStubbed("PrivateCustomServiceInboxWebhookWithContext")
+}
@@ -0,0 +1,201 @@
+//go:build ignore
+// +build ignore
+
+// mock_gen generates mock.go from the API interface in api.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+const header = `// Code generated by mock_gen.go; DO NOT EDIT.
+
+package mailinator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Mock is an API for tests. Each method calls the function in the field of the same name
+// with a Func suffix. When it is not set, a method without context falls back to the
+// WithContext function, and otherwise an error matching ErrNotStubbed is returned.
+// Every call is recorded. A Mock is safe for concurrent use once its functions are set.
+type Mock struct {
+%s
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+var _ API = (*Mock)(nil)
+
+// MockCall is a call recorded by a Mock.
+type MockCall struct {
+	// Method is the name of the method called.
+	Method string
+	// Args holds the arguments of the call.
+	Args []interface{}
+}
+
+// Calls returns the calls made so far, in order.
+func (m *Mock) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the calls made so far to method, in order.
+func (m *Mock) CallsTo(method string) []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []MockCall
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets the calls made so far.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%%w: %%s", ErrNotStubbed, method)
+}
+`
+
+type method struct {
+	name    string
+	params  []string
+	names   []string
+	results []string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "api.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var methods []method
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "API" {
+			return true
+		}
+
+		for _, field := range spec.Type.(*ast.InterfaceType).Methods.List {
+			fn := field.Type.(*ast.FuncType)
+			m := method{name: field.Names[0].Name}
+			for _, param := range fn.Params.List {
+				for _, name := range param.Names {
+					m.names = append(m.names, name.Name)
+					m.params = append(m.params, name.Name+" "+expr(fset, param.Type))
+				}
+			}
+			for _, result := range fn.Results.List {
+				m.results = append(m.results, expr(fset, result.Type))
+			}
+			methods = append(methods, m)
+		}
+
+		return false
+	})
+
+	names := map[string]bool{}
+	for _, m := range methods {
+		names[m.name] = true
+	}
+
+	var fields, funcs bytes.Buffer
+	for _, m := range methods {
+		signature := "(" + strings.Join(m.params, ", ") + ") " + results(m.results)
+		fmt.Fprintf(&fields, "\t%sFunc func%s\n", m.name, signature)
+
+		args := strings.Join(m.names, ", ")
+		fmt.Fprintf(&funcs, "\n// %s calls %sFunc and records the call.\n", m.name, m.name)
+		fmt.Fprintf(&funcs, "func (m *Mock) %s%s {\n", m.name, signature)
+		fmt.Fprintf(&funcs, "\tm.record(%q%s)\n", m.name, prefixed(args))
+		fmt.Fprintf(&funcs, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", m.name, m.name, args)
+		if withContext := m.name + "WithContext"; names[withContext] {
+			fmt.Fprintf(&funcs, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(context.Background()%s)\n\t}\n", withContext, withContext, prefixed(args))
+		}
+		fmt.Fprintf(&funcs, "\treturn %s\n}\n", zeros(m.name, m.results))
+	}
+
+	src := fmt.Sprintf(header, fields.String()) + funcs.String()
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile("mock.go", formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func expr(fset *token.FileSet, node ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
+
+func results(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+
+	return "(" + strings.Join(types, ", ") + ")"
+}
+
+func prefixed(args string) string {
+	if args == "" {
+		return ""
+	}
+
+	return ", " + args
+}
+
+func zeros(name string, types []string) string {
+	values := make([]string, len(types))
+	for i, typ := range types {
+		switch {
+		case typ == "error":
+			values[i] = fmt.Sprintf("notStubbed(%q)", name)
+		case strings.HasPrefix(typ, "*"):
+			values[i] = "nil"
+		default:
+			values[i] = "*new(" + typ + ")"
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,80 @@
+package mailinator
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL   = "https://api.mailinator.com/api/v2"
+	defaultUserAgent = "Mailinator SDK - Go V1.1"
+	defaultTimeout   = 2 * time.Minute
+)
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithBaseURL points the client at another API root, e.g. a local stub or a recording proxy.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient makes the client send its requests through httpClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sets the transport of the client's HTTPClient.
+// A client given with WithHTTPClient is copied rather than changed.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.HTTPClient = copyHTTPClient(c.HTTPClient)
+		c.HTTPClient.Transport = transport
+	}
+}
+
+// WithTimeout sets the timeout of the client's HTTPClient. Zero means no timeout.
+// A client given with WithHTTPClient is copied rather than changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient = copyHTTPClient(c.HTTPClient)
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// copyHTTPClient returns a copy of httpClient, which may be shared, such as http.DefaultClient, or nil.
+func copyHTTPClient(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		return &http.Client{}
+	}
+
+	clone := *httpClient
+	return &clone
+}
+
+// WithUserAgent appends suffix to the User-Agent sent with every request.
+func WithUserAgent(suffix string) Option {
+	return func(c *Client) {
+		c.userAgent = defaultUserAgent + " " + suffix
+	}
+}
+
+// WithHeader adds a header sent with every request.
+// Headers set by the client itself, such as Authorization, take precedence.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithRetryPolicy sets the client's RetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
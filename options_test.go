@@ -0,0 +1,25 @@
+package mailinator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutCopiesHTTPClient(t *testing.T) {
+	shared := &http.Client{}
+	transport := &http.Transport{}
+
+	c := NewClient("token", WithHTTPClient(shared), WithTimeout(time.Second), WithTransport(transport))
+	assert.Equal(t, time.Duration(0), shared.Timeout, "expecting shared client unchanged")
+	assert.Nil(t, shared.Transport, "expecting shared transport unchanged")
+	assert.Equal(t, time.Second, c.HTTPClient.Timeout, "expecting timeout set")
+	assert.Equal(t, transport, c.HTTPClient.Transport, "expecting transport set")
+}
+
+func TestWithTimeoutAfterNilHTTPClient(t *testing.T) {
+	c := NewClient("token", WithHTTPClient(nil), WithTimeout(time.Second))
+	assert.Equal(t, time.Second, c.HTTPClient.Timeout, "expecting timeout set")
+}
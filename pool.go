@@ -0,0 +1,312 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoHealthyClient is returned by a Pool when every client has been marked unhealthy.
+var ErrNoHealthyClient = errors.New("mailinator: no healthy client in pool")
+
+// PoolStrategy selects how a Pool spreads calls across its clients.
+type PoolStrategy int
+
+const (
+	// RoundRobin hands out clients in turn.
+	RoundRobin PoolStrategy = iota
+	// LeastUsed hands out the client with the fewest calls in flight, then the fewest calls overall.
+	LeastUsed
+)
+
+// PoolSettings configures a Pool.
+type PoolSettings struct {
+	// Strategy selects how calls are spread across clients.
+	Strategy PoolStrategy
+	// Recheck is how long a client whose token was rejected stays out of rotation
+	// before it is tried again. Zero means 5 minutes.
+	Recheck time.Duration
+}
+
+// PoolMemberStatus describes a client of a Pool.
+type PoolMemberStatus struct {
+	Client   *Client
+	Healthy  bool
+	InFlight int
+	Calls    int
+	Domains  []string
+}
+
+type poolMember struct {
+	client    *Client
+	inFlight  int
+	calls     int
+	healthy   bool
+	failedAt  time.Time
+	domains   []string
+	lastError error
+}
+
+// Pool spreads calls across clients holding the tokens of different teams.
+// Calls for a private domain are routed to the client whose team owns it.
+// Each client keeps its own options, so per-token limits are set with WithRateLimiter
+// or WithCircuitBreaker when creating it. A Pool is safe for concurrent use.
+type Pool struct {
+	settings PoolSettings
+
+	mu      sync.Mutex
+	members []*poolMember
+	owners  map[string]*poolMember
+	next    int
+
+	// refreshFailures counts the consecutive RefreshDomains that failed to look up a team.
+	// Until one succeeds, owners may be incomplete and is looked up again from refreshAt.
+	refreshFailures int
+	refreshAt       time.Time
+}
+
+// NewPool returns a Pool spreading calls across clients.
+func NewPool(clients []*Client, settings PoolSettings) *Pool {
+	if settings.Recheck <= 0 {
+		settings.Recheck = 5 * time.Minute
+	}
+
+	p := &Pool{settings: settings}
+	for _, client := range clients {
+		p.members = append(p.members, &poolMember{client: client, healthy: true})
+	}
+
+	return p
+}
+
+// RefreshDomains looks up the private domains of every team with GetTeamInfo, so that
+// domain-scoped calls can be routed to their owner. Clients whose token is rejected are
+// marked unhealthy; other failures are returned once all clients have been tried, and the
+// lookup is retried by later domain-scoped calls, backing off from 1 second up to Recheck.
+func (p *Pool) RefreshDomains(ctx context.Context) error {
+	p.mu.Lock()
+	members := append([]*poolMember(nil), p.members...)
+	p.mu.Unlock()
+
+	owners := map[string]*poolMember{}
+	var firstErr error
+
+	for _, member := range members {
+		info, err := member.client.GetTeamInfoWithContext(ctx)
+		if err != nil {
+			p.report(member, err)
+			if firstErr == nil && !errors.Is(err, ErrUnauthorized) {
+				firstErr = err
+			}
+			continue
+		}
+
+		p.mu.Lock()
+		member.domains = info.Domains
+		p.mu.Unlock()
+
+		for _, domain := range info.Domains {
+			owners[strings.ToLower(domain)] = member
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if firstErr == nil {
+		p.owners = owners
+		p.refreshFailures = 0
+		return nil
+	}
+
+	// Keep the owners known so far rather than forgetting them over a transient failure.
+	if p.owners == nil {
+		p.owners = map[string]*poolMember{}
+	}
+	for domain, member := range owners {
+		p.owners[domain] = member
+	}
+
+	p.refreshFailures++
+	delay := time.Second
+	for i := 1; i < p.refreshFailures && delay < p.settings.Recheck; i++ {
+		delay *= 2
+	}
+	if delay > p.settings.Recheck {
+		delay = p.settings.Recheck
+	}
+	p.refreshAt = time.Now().Add(delay)
+
+	return firstErr
+}
+
+// Do calls fn with a client of the pool. If domain is owned by one of the teams, that team's
+// client is used; otherwise one is picked according to the pool strategy. When fn fails because
+// the token was rejected, the client is taken out of rotation and fn is retried with another
+// client, unless the domain is owned by the rejected team.
+func (p *Pool) Do(ctx context.Context, domain string, fn func(*Client) error) error {
+	if domain != "" && p.ownersStale() {
+		if err := p.RefreshDomains(ctx); err != nil && !p.healthyLeft() {
+			return err
+		}
+	}
+
+	tried := map[*poolMember]bool{}
+
+	for {
+		member, owned, err := p.acquire(domain, tried)
+		if err != nil {
+			return err
+		}
+
+		err = fn(member.client)
+		p.release(member)
+		p.report(member, err)
+
+		if err == nil || !errors.Is(err, ErrUnauthorized) || owned {
+			return err
+		}
+
+		tried[member] = true
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// Client returns a client of the pool for domain, chosen as in Do. Unlike Do, it cannot
+// move on to another client when the token is rejected.
+func (p *Pool) Client(domain string) (*Client, error) {
+	member, _, err := p.acquire(domain, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p.release(member)
+	return member.client, nil
+}
+
+// Status returns the state of every client of the pool.
+func (p *Pool) Status() []PoolMemberStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]PoolMemberStatus, 0, len(p.members))
+	for _, member := range p.members {
+		statuses = append(statuses, PoolMemberStatus{
+			Client:   member.client,
+			Healthy:  p.available(member, now),
+			InFlight: member.inFlight,
+			Calls:    member.calls,
+			Domains:  append([]string(nil), member.domains...),
+		})
+	}
+
+	return statuses
+}
+
+// ownersStale reports whether the owners of the domains must be looked up before routing a call.
+func (p *Pool) ownersStale() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.owners == nil {
+		return true
+	}
+
+	return p.refreshFailures > 0 && !time.Now().Before(p.refreshAt)
+}
+
+func (p *Pool) healthyLeft() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for _, member := range p.members {
+		if p.available(member, now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acquire picks a client for domain, skipping the ones in tried, and marks it in flight.
+// It also reports whether the client was picked because its team owns the domain.
+func (p *Pool) acquire(domain string, tried map[*poolMember]bool) (*poolMember, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	if owner, ok := p.owners[strings.ToLower(domain)]; ok && domain != "" {
+		if !p.available(owner, now) {
+			return nil, true, owner.lastError
+		}
+		owner.inFlight++
+		owner.calls++
+		return owner, true, nil
+	}
+
+	var picked *poolMember
+	for i := range p.members {
+		index := i
+		if p.settings.Strategy == RoundRobin {
+			index = (p.next + i) % len(p.members)
+		}
+
+		member := p.members[index]
+		if tried[member] || !p.available(member, now) {
+			continue
+		}
+
+		if p.settings.Strategy == RoundRobin {
+			picked = member
+			p.next = index + 1
+			break
+		}
+
+		if picked == nil || member.inFlight < picked.inFlight || (member.inFlight == picked.inFlight && member.calls < picked.calls) {
+			picked = member
+		}
+	}
+
+	if picked == nil {
+		return nil, false, ErrNoHealthyClient
+	}
+
+	picked.inFlight++
+	picked.calls++
+	return picked, false, nil
+}
+
+func (p *Pool) release(member *poolMember) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	member.inFlight--
+}
+
+// report takes a client out of rotation when its token is rejected, and back in when it works.
+func (p *Pool) report(member *poolMember, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case err == nil:
+		member.healthy = true
+		member.lastError = nil
+	case errors.Is(err, ErrUnauthorized):
+		member.healthy = false
+		member.failedAt = time.Now()
+		member.lastError = err
+	}
+}
+
+// available reports whether member may be used, giving unhealthy clients another chance after Recheck.
+func (p *Pool) available(member *poolMember, now time.Time) bool {
+	return member.healthy || now.Sub(member.failedAt) >= p.settings.Recheck
+}
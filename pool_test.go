@@ -0,0 +1,55 @@
+package mailinator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolRetriesFailedDomainLookup(t *testing.T) {
+	var lookups int32
+	owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&lookups, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"private_domains":["owned.example.com"]}`))
+	}))
+	defer owner.Close()
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"private_domains":[]}`))
+	}))
+	defer other.Close()
+
+	ownerClient := NewClient("owner", WithBaseURL(owner.URL))
+	otherClient := NewClient("other", WithBaseURL(other.URL))
+	p := NewPool([]*Client{ownerClient, otherClient}, PoolSettings{})
+
+	var used *Client
+	use := func(c *Client) error {
+		used = c
+		return nil
+	}
+
+	assert.Nil(t, p.Do(context.Background(), "owned.example.com", use), "expecting nil error")
+	assert.False(t, p.ownersStale(), "expecting backoff before the next lookup")
+
+	// Skip the backoff.
+	p.mu.Lock()
+	p.refreshAt = time.Now()
+	p.mu.Unlock()
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, p.Do(context.Background(), "owned.example.com", use), "expecting nil error")
+		assert.Equal(t, ownerClient, used, "expecting call routed to the owner")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&lookups), "expecting a single retried lookup")
+}
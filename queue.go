@@ -0,0 +1,457 @@
+package mailinator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrQueueClosed is returned when a write is queued on a closed WriteQueue.
+var ErrQueueClosed = errors.New("mailinator: write queue is closed")
+
+// QueueSettings configures a WriteQueue.
+type QueueSettings struct {
+	// Path is the append-only file pending writes are persisted to. It is created if missing.
+	Path string
+	// MinBackoff is the delay before retrying a failed write. It doubles on every further retry. Zero means 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. Zero means 1 minute.
+	MaxBackoff time.Duration
+	// OnResult, if set, is called with the result of every write, in order, from the goroutine delivering them.
+	// Writes wait while it runs.
+	OnResult func(QueueResult)
+	// Results, if set, is sent the result of every write after OnResult. Writes wait while it is full.
+	Results chan<- QueueResult
+}
+
+// QueueResult is the outcome of a write queued on a WriteQueue.
+type QueueResult struct {
+	// ID is the id returned when the write was queued.
+	ID uint64
+	// Endpoint is the name of the Client method, "PostMessage" or "PrivateWebhook".
+	Endpoint string
+	// Attempts is the number of attempts made since the queue was opened.
+	Attempts int
+	// PostedMessage is the result of a delivered PostMessage.
+	PostedMessage *PostedMessage
+	// Webhook is the result of a delivered PrivateWebhook.
+	Webhook *ResponseStatusWithId
+	// Err is the error of a write the API rejected, which is dropped. It is nil if the write was delivered.
+	Err error
+}
+
+// queueRecord is a line of the queue file: a queued write, or the acknowledgement of write ID if Done is set.
+// Acknowledgements also keep ids from being handed out again once the file is compacted.
+type queueRecord struct {
+	ID             uint64                 `json:"id"`
+	Endpoint       string                 `json:"endpoint,omitempty"`
+	PostMessage    *PostMessageOptions    `json:"post_message,omitempty"`
+	PrivateWebhook *PrivateWebhookOptions `json:"private_webhook,omitempty"`
+	Done           bool                   `json:"done,omitempty"`
+
+	attempts int
+}
+
+// WriteQueue delivers PostMessage and PrivateWebhook calls in the background, in the order they were
+// queued. Writes are persisted to a local append-only file before being acknowledged, and retried
+// until the API accepts or rejects them, so that they survive network outages and restarts.
+//
+// Transport errors, 429 and 5xx responses, client-side rate limits and open circuit breakers are retried.
+// Other errors drop the write, including responses that could not be decoded, as the API may have accepted it.
+// Delivery is at least once: a write delivered just before a crash may be sent again on restart.
+// The file holds the webhook tokens of queued PrivateWebhook calls and is only readable by its owner.
+// A WriteQueue is safe for concurrent use.
+type WriteQueue struct {
+	client   API
+	settings QueueSettings
+
+	mu      sync.Mutex
+	file    *os.File
+	pending []*queueRecord
+	nextID  uint64
+	closed  bool
+	wake    chan struct{}
+	idle    chan struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWriteQueue opens the queue file of settings, resumes the writes it holds and starts delivering
+// them through client. Zero backoffs get defaults.
+func NewWriteQueue(client API, settings QueueSettings) (*WriteQueue, error) {
+	if settings.MinBackoff <= 0 {
+		settings.MinBackoff = time.Second
+	}
+	if settings.MaxBackoff <= 0 {
+		settings.MaxBackoff = time.Minute
+	}
+
+	pending, nextID, err := loadQueue(settings.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := compactQueue(settings.Path, pending, nextID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &WriteQueue{
+		client:   client,
+		settings: settings,
+		file:     file,
+		pending:  pending,
+		nextID:   nextID,
+		wake:     make(chan struct{}, 1),
+		idle:     make(chan struct{}),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	if len(pending) == 0 {
+		close(q.idle)
+	}
+
+	go q.run(ctx)
+
+	return q, nil
+}
+
+// loadQueue reads the writes of the queue file at path that were not acknowledged, in order,
+// and the id of the next write. A missing file holds no writes.
+func loadQueue(path string) ([]*queueRecord, uint64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 1, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var pending []*queueRecord
+	nextID := uint64(1)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		var record queueRecord
+		// A partial last line, left by a crash while appending, was never acknowledged to the caller.
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+
+		if record.ID >= nextID {
+			nextID = record.ID + 1
+		}
+
+		if !record.Done {
+			if record.PostMessage == nil && record.PrivateWebhook == nil {
+				continue
+			}
+			pending = append(pending, &record)
+			continue
+		}
+
+		for i, write := range pending {
+			if write.ID == record.ID {
+				pending = append(pending[:i], pending[i+1:]...)
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return pending, nextID, nil
+}
+
+// compactQueue replaces the queue file at path with one holding only pending, and the acknowledgement
+// of the last id handed out before nextID, and returns it open for appending. The new file is written
+// and opened before it replaces the old one, so that on error the old one is still in place and in use.
+func compactQueue(path string, pending []*queueRecord, nextID uint64) (*os.File, error) {
+	tmp, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeQueue(tmp, pending, nextID); err != nil {
+		tmp.Close()
+		os.Remove(path + ".tmp")
+		return nil, err
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		tmp.Close()
+		os.Remove(path + ".tmp")
+		return nil, err
+	}
+
+	return tmp, nil
+}
+
+// writeQueue writes pending, preceded by the acknowledgement of the last id handed out before nextID,
+// to the empty file and syncs it.
+func writeQueue(file *os.File, pending []*queueRecord, nextID uint64) error {
+	w := bufio.NewWriter(file)
+	encoder := json.NewEncoder(w)
+	if nextID > 1 {
+		if err := encoder.Encode(&queueRecord{ID: nextID - 1, Done: true}); err != nil {
+			return err
+		}
+	}
+	for _, record := range pending {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+// PostMessage queues a PostMessage call and returns the id its QueueResult will carry.
+// It returns once the write is persisted.
+func (q *WriteQueue) PostMessage(options *PostMessageOptions) (uint64, error) {
+	return q.enqueue(&queueRecord{Endpoint: "PostMessage", PostMessage: options})
+}
+
+// PrivateWebhook queues a PrivateWebhook call and returns the id its QueueResult will carry.
+// It returns once the write is persisted.
+func (q *WriteQueue) PrivateWebhook(options *PrivateWebhookOptions) (uint64, error) {
+	return q.enqueue(&queueRecord{Endpoint: "PrivateWebhook", PrivateWebhook: options})
+}
+
+func (q *WriteQueue) enqueue(record *queueRecord) (uint64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return 0, ErrQueueClosed
+	}
+
+	record.ID = q.nextID
+	if err := q.append(record, true); err != nil {
+		return 0, err
+	}
+	q.nextID++
+
+	// A new idle signal is needed once the last one was given; until then, Flush keeps waiting on it.
+	select {
+	case <-q.idle:
+		q.idle = make(chan struct{})
+	default:
+	}
+	q.pending = append(q.pending, record)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return record.ID, nil
+}
+
+// append writes record to the queue file, syncing it to disk if sync is set. q.mu must be held.
+func (q *WriteQueue) append(record *queueRecord, sync bool) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if sync {
+		return q.file.Sync()
+	}
+
+	return nil
+}
+
+// Len returns the number of writes not yet delivered or dropped.
+func (q *WriteQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending)
+}
+
+// Flush waits until every queued write has been delivered or dropped and its result reported
+// to OnResult and Results, or ctx is done.
+func (q *WriteQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	idle := q.idle
+	q.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops delivering writes and closes the queue file. Writes not yet delivered stay in the file
+// and are resumed by the next WriteQueue opened on it.
+func (q *WriteQueue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	q.cancel()
+	<-q.done
+
+	return q.file.Close()
+}
+
+// run delivers the pending writes in order until ctx is cancelled.
+func (q *WriteQueue) run(ctx context.Context) {
+	defer close(q.done)
+
+	for {
+		q.mu.Lock()
+		var record *queueRecord
+		if len(q.pending) > 0 {
+			record = q.pending[0]
+		}
+		q.mu.Unlock()
+
+		if record == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.wake:
+			}
+			continue
+		}
+
+		result, ok := q.deliver(ctx, record)
+		if !ok {
+			return
+		}
+
+		q.ack(record)
+
+		if q.settings.OnResult != nil {
+			q.settings.OnResult(result)
+		}
+		if q.settings.Results != nil {
+			select {
+			case q.settings.Results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			select {
+			case <-q.idle:
+			default:
+				close(q.idle)
+			}
+		}
+		q.mu.Unlock()
+	}
+}
+
+// deliver sends record until the API accepts or rejects it. It returns false if ctx was cancelled first.
+func (q *WriteQueue) deliver(ctx context.Context, record *queueRecord) (QueueResult, bool) {
+	result := QueueResult{ID: record.ID, Endpoint: record.Endpoint}
+
+	for retry := 0; ; retry++ {
+		record.attempts++
+
+		var err error
+		switch {
+		case record.PostMessage != nil:
+			result.PostedMessage, err = q.client.PostMessageWithContext(ctx, record.PostMessage)
+		case record.PrivateWebhook != nil:
+			result.Webhook, err = q.client.PrivateWebhookWithContext(ctx, record.PrivateWebhook)
+		}
+
+		if ctx.Err() != nil {
+			return result, false
+		}
+
+		result.Attempts = record.attempts
+		if err == nil || !retryableWrite(err) {
+			result.Err = err
+			return result, true
+		}
+
+		timer := time.NewTimer(q.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, false
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the given retry (0 for the first retry), randomized like RetryPolicy's.
+func (q *WriteQueue) backoff(retry int) time.Duration {
+	delay := q.settings.MinBackoff
+	for i := 0; i < retry && delay < q.settings.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > q.settings.MaxBackoff {
+		delay = q.settings.MaxBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// ack removes record from the pending writes and records it as done, compacting the file once no write is pending.
+func (q *WriteQueue) ack(record *queueRecord) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = q.pending[1:]
+
+	if len(q.pending) == 0 {
+		// Only the last id handed out is needed anymore.
+		if file, err := compactQueue(q.settings.Path, nil, q.nextID); err == nil {
+			q.file.Close()
+			q.file = file
+			return
+		}
+	}
+
+	// An acknowledgement lost in a crash only makes the write be sent again, so it is not synced.
+	q.append(&queueRecord{ID: record.ID, Done: true}, false)
+}
+
+// retryableWrite reports whether a write that failed with err may succeed later. Writes that may
+// have been accepted, such as those whose response could not be decoded, are never retried.
+func retryableWrite(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return errors.Is(apiErr, ErrRateLimited) || errors.Is(apiErr, ErrServer)
+	}
+
+	var urlErr *url.Error
+	var limitErr *LimitError
+	var openErr *CircuitOpenError
+
+	return errors.As(err, &urlErr) || errors.As(err, &limitErr) || errors.As(err, &openErr)
+}
@@ -0,0 +1,205 @@
+package mailinator
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestQueue opens a WriteQueue in a temporary directory, delivering through a client of server.
+func newTestQueue(t *testing.T, server *httptest.Server, dir string, results chan<- QueueResult) *WriteQueue {
+	c := NewClient("token", WithBaseURL(server.URL))
+	q, err := NewWriteQueue(c, QueueSettings{
+		Path:       filepath.Join(dir, "writes.log"),
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+		Results:    results,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return q
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "mailinator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestWriteQueueDropsUndecodableResponse(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("<html>accepted</html>"))
+	}))
+	defer server.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	results := make(chan QueueResult, 1)
+	q := newTestQueue(t, server, dir, results)
+	defer q.Close()
+
+	_, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.Nil(t, err, "expecting nil error")
+
+	result := <-results
+	assert.NotNil(t, result.Err, "expecting dropped write")
+	assert.Equal(t, 1, result.Attempts, "expecting a single attempt")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "expecting a single request")
+}
+
+func TestWriteQueueRetriesServerErrors(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","id":"1"}`))
+	}))
+	defer server.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	results := make(chan QueueResult, 1)
+	q := newTestQueue(t, server, dir, results)
+	defer q.Close()
+
+	_, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.Nil(t, err, "expecting nil error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+
+	result := <-results
+	assert.Nil(t, result.Err, "expecting delivered write")
+	assert.Equal(t, 3, result.Attempts, "expecting retried write")
+}
+
+func TestWriteQueueKeepsIDsAcrossRestarts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","id":"1"}`))
+	}))
+	defer server.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	q := newTestQueue(t, server, dir, nil)
+	for i := 0; i < 2; i++ {
+		_, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+		assert.Nil(t, err, "expecting nil error")
+	}
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+	assert.Nil(t, q.Close(), "expecting closed queue")
+
+	for restart := 0; restart < 2; restart++ {
+		q = newTestQueue(t, server, dir, nil)
+		id, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+		assert.Nil(t, err, "expecting nil error")
+		assert.Equal(t, uint64(3+restart), id, "expecting id not handed out before")
+		assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+		assert.Nil(t, q.Close(), "expecting closed queue")
+	}
+}
+
+func TestWriteQueueFlushWaitsForResults(t *testing.T) {
+	server := newDomainsServer(new(int32), nil)
+	defer server.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	var mu sync.Mutex
+	var reported []uint64
+	c := NewClient("token", WithBaseURL(server.URL))
+	q, err := NewWriteQueue(c, QueueSettings{
+		Path: filepath.Join(dir, "writes.log"),
+		OnResult: func(result QueueResult) {
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			reported = append(reported, result.ID)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+		assert.Nil(t, err, "expecting nil error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []uint64{1, 2, 3}, reported, "expecting every result reported before Flush returns")
+}
+
+func TestWriteQueueKeepsFileWhenCompactionFails(t *testing.T) {
+	server := newDomainsServer(new(int32), nil)
+	defer server.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	q := newTestQueue(t, server, dir, nil)
+
+	// A directory in the way of the compacted file makes compaction fail.
+	tmp := filepath.Join(dir, "writes.log.tmp")
+	if err := os.Mkdir(tmp, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.Nil(t, err, "expecting nil error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+
+	_, err = q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Nil(t, q.Flush(ctx), "expecting flushed queue")
+	assert.Nil(t, q.Close(), "expecting closed queue")
+
+	if err := os.Remove(tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	q = newTestQueue(t, server, dir, nil)
+	defer q.Close()
+	assert.Equal(t, 0, q.Len(), "expecting acknowledgements kept in the old file")
+	id, err := q.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, uint64(3), id, "expecting ids kept in the old file")
+}
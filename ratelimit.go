@@ -0,0 +1,225 @@
+package mailinator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrReadBudgetExhausted is matched by a *LimitError returned when the daily read budget is used up.
+var ErrReadBudgetExhausted = errors.New("mailinator: daily read budget exhausted")
+
+// LimitError is returned when the client-side RateLimiter refuses a request.
+// It matches ErrRateLimited through errors.Is, and ErrReadBudgetExhausted when the daily budget is used up.
+type LimitError struct {
+	// Budget is true when the daily read budget is exhausted, false when the request rate is exceeded.
+	Budget bool
+	// RetryAfter is how long until the request would be allowed.
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	if e.Budget {
+		return ErrReadBudgetExhausted.Error()
+	}
+
+	return "mailinator: client rate limit exceeded"
+}
+
+// Is reports whether target is ErrRateLimited, or ErrReadBudgetExhausted for budget errors.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrRateLimited || (e.Budget && target == ErrReadBudgetExhausted)
+}
+
+// emailReadEndpoints lists the Client methods counted against the daily read budget.
+var emailReadEndpoints = map[string]bool{
+	"FetchInboxMessage":        true,
+	"FetchMessage":             true,
+	"FetchSMSMessage":          true,
+	"FetchInboxMessageRaw":     true,
+	"FetchMessageRaw":          true,
+	"StreamInboxMessageRaw":    true,
+	"StreamMessageRaw":         true,
+	"FetchLatestMessages":      true,
+	"FetchLatestInboxMessages": true,
+}
+
+// RateLimiter is a token-bucket limiter with an optional daily read budget.
+// A single RateLimiter may be shared by several clients and goroutines.
+type RateLimiter struct {
+	// FailFast makes requests fail with a *LimitError instead of waiting for the limiter.
+	FailFast bool
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	readBudget int
+	readsUsed  int
+	budgetDay  time.Time
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond requests on average, with bursts of up to burst requests.
+// A requestsPerSecond of zero or less disables the rate limit, leaving only the daily read budget.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SetDailyReadBudget limits the number of email reads per UTC day. Zero means no limit.
+func (l *RateLimiter) SetDailyReadBudget(reads int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.readBudget = reads
+}
+
+// SetDailyReadBudgetFromPlan seeds the daily read budget from a team's plan, as returned by GetTeam.
+func (l *RateLimiter) SetDailyReadBudgetFromPlan(plan PlanData) {
+	l.SetDailyReadBudget(plan.EmailReadsPerDay)
+}
+
+// ReadsUsed returns the number of email reads made today through the limiter.
+func (l *RateLimiter) ReadsUsed() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resetBudget(time.Now())
+	return l.readsUsed
+}
+
+// Wait blocks until a request for the given endpoint is allowed, ctx is done,
+// or, with FailFast set, returns a *LimitError right away.
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	read := emailReadEndpoints[endpoint]
+
+	for {
+		delay, reserved, err := l.reserve(ctx, read)
+		if err != nil {
+			return err
+		}
+
+		if reserved && delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if reserved {
+				l.cancel(read)
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if reserved {
+			return nil
+		}
+	}
+}
+
+// reserve takes a token, and a read from the budget if read is set, and returns
+// how long the caller must wait before using the reservation. When the read budget
+// is exhausted nothing is reserved and the delay is the time until the budget resets.
+func (l *RateLimiter) reserve(ctx context.Context, read bool) (time.Duration, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.resetBudget(now)
+
+	if read && l.readBudget > 0 && l.readsUsed >= l.readBudget {
+		delay := l.budgetDay.AddDate(0, 0, 1).Sub(now)
+		if err := l.refuse(ctx, delay, true); err != nil {
+			return 0, false, err
+		}
+		return delay, false, nil
+	}
+
+	var delay time.Duration
+	if l.rate > 0 {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens < 1 {
+			delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+			if err := l.refuse(ctx, delay, false); err != nil {
+				return 0, false, err
+			}
+		}
+		l.tokens--
+	}
+
+	if read {
+		l.readsUsed++
+	}
+
+	return delay, true, nil
+}
+
+// refuse returns a *LimitError if the caller cannot or will not wait for delay.
+func (l *RateLimiter) refuse(ctx context.Context, delay time.Duration, budget bool) error {
+	if l.FailFast {
+		return &LimitError{Budget: budget, RetryAfter: delay}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return &LimitError{Budget: budget, RetryAfter: delay}
+	}
+
+	return nil
+}
+
+// cancel gives back a reservation whose wait was abandoned.
+func (l *RateLimiter) cancel(read bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate > 0 {
+		l.tokens++
+	}
+	if read && l.readsUsed > 0 {
+		l.readsUsed--
+	}
+}
+
+func (l *RateLimiter) resetBudget(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(l.budgetDay) {
+		l.budgetDay = day
+		l.readsUsed = 0
+	}
+}
+
+// WithRateLimiter makes the client wait for limiter before sending each request, including retries.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// wait applies the client's RateLimiter, if any, to req.
+func (c *Client) wait(req *http.Request) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	return c.limiter.Wait(req.Context(), EndpointFromContext(req.Context()))
+}
@@ -0,0 +1,134 @@
+package mailinator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveQueryParams lists the query parameters that carry credentials.
+var sensitiveQueryParams = []string{"whtoken", "token"}
+
+// sensitiveHeaders lists the headers that carry credentials, in requests or responses.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactURL returns u as a string with all credentials replaced.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(redacted)
+	}
+
+	query := clean.Query()
+	changed := false
+	for _, param := range sensitiveQueryParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+
+	return clean.String()
+}
+
+// redactHeader returns a copy of header with all credentials replaced.
+func redactHeader(header http.Header) http.Header {
+	clean := header.Clone()
+	for _, key := range sensitiveHeaders {
+		if clean.Get(key) != "" {
+			clean.Set(key, redacted)
+		}
+	}
+
+	return clean
+}
+
+// redactError removes credentials from the URL reported by transport errors.
+func redactError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		clean := *urlErr
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			clean.URL = redactURL(u)
+		}
+		return &clean
+	}
+
+	return err
+}
+
+// String describes the client without revealing its API token. It has a value receiver so that
+// printing a Client, not only a *Client, does not reveal the token either.
+func (c Client) String() string {
+	return fmt.Sprintf("mailinator.Client{baseURL: %q, apiToken: %s}", c.baseURL, redacted)
+}
+
+// GoString is like String, so that %#v does not reveal the API token either.
+func (c Client) GoString() string {
+	return c.String()
+}
+
+// String prints the client with its webhook token redacted.
+func (w WebhookClient) String() string {
+	return fmt.Sprintf("mailinator.WebhookClient{baseURL: %q, webhookToken: %s}", w.client.baseURL, redacted)
+}
+
+// GoString is like String, so that %#v does not reveal the webhook token either.
+func (w WebhookClient) GoString() string {
+	return w.String()
+}
+
+// String describes the provider without revealing its token.
+func (s staticCredentials) String() string {
+	return "mailinator.StaticCredentials(" + redacted + ")"
+}
+
+// GoString is like String, so that %#v does not reveal the token either.
+func (s staticCredentials) GoString() string {
+	return s.String()
+}
+
+// String describes the provider without revealing the token it read.
+func (f *FileCredentials) String() string {
+	return fmt.Sprintf("mailinator.FileCredentials{path: %q, token: %s}", f.path, redacted)
+}
+
+// GoString is like String, so that %#v does not reveal the token either.
+func (f *FileCredentials) GoString() string {
+	return f.String()
+}
+
+// String describes the provider without revealing the token it got.
+func (c *CommandCredentials) String() string {
+	return fmt.Sprintf("mailinator.CommandCredentials{name: %q, token: %s}", c.name, redacted)
+}
+
+// GoString is like String, so that %#v does not reveal the token either.
+func (c *CommandCredentials) GoString() string {
+	return c.String()
+}
+
+// teamInfo has the fields of TeamInfo but not its methods, so it can be printed without recursion.
+type teamInfo TeamInfo
+
+// String prints the team info with its token redacted.
+func (t TeamInfo) String() string {
+	if t.Token != "" {
+		t.Token = redacted
+	}
+
+	return fmt.Sprintf("%+v", teamInfo(t))
+}
+
+// GoString is like String, so that %#v does not reveal the token either.
+func (t TeamInfo) GoString() string {
+	return t.String()
+}
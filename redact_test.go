@@ -0,0 +1,45 @@
+package mailinator
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintingHidesTokens(t *testing.T) {
+	c := NewClient("super-secret")
+	w := NewWebhookClient("super-secret")
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		for _, value := range []interface{}{c, *c, c.credentials, w, *w} {
+			assert.NotContains(t, fmt.Sprintf(verb, value), "super-secret", "expecting redacted token with "+verb)
+		}
+	}
+}
+
+func TestPrintingFileCredentialsHidesToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailinator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(path, []byte("super-secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := NewFileCredentials(path)
+	token, err := credentials.Token(context.Background())
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, "super-secret", token, "expecting token read")
+
+	for _, verb := range []string{"%v", "%+v", "%#v"} {
+		assert.NotContains(t, fmt.Sprintf(verb, credentials), "super-secret", "expecting redacted token with "+verb)
+	}
+}
@@ -0,0 +1,96 @@
+package mailinator
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response holds the metadata of the HTTP response received by a Client call.
+type Response struct {
+	// StatusCode is the status code of the last response, or 0 if none was received.
+	StatusCode int
+	// Header holds the headers of the last response.
+	Header http.Header
+	// RequestID is the request identifier reported by the server, if any.
+	RequestID string
+	// RateLimit holds the rate-limit counters reported by the server, if any.
+	RateLimit RateLimit
+	// Elapsed is the total duration of the call, including retries.
+	Elapsed time.Duration
+	// Retries is the number of retries made.
+	Retries int
+}
+
+// RateLimit holds the rate-limit counters reported in response headers.
+// Fields are zero when the server did not report them.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+type responseKey struct{}
+
+// CaptureResponse returns a context that makes the Client call it is passed to
+// store the metadata of its response in res.
+func CaptureResponse(ctx context.Context, res *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, res)
+}
+
+func captureResponse(ctx context.Context, res *http.Response, retries int, elapsed time.Duration) {
+	captured, ok := ctx.Value(responseKey{}).(*Response)
+	if !ok || captured == nil {
+		return
+	}
+
+	*captured = Response{
+		Elapsed: elapsed,
+		Retries: retries,
+	}
+
+	if res == nil {
+		return
+	}
+
+	captured.StatusCode = res.StatusCode
+	captured.Header = res.Header
+	captured.RequestID = firstHeader(res.Header, "X-Request-Id", "X-Amzn-Requestid", "X-Correlation-Id")
+	captured.RateLimit = parseRateLimit(res.Header)
+}
+
+// parseRateLimit reads the X-RateLimit-* headers, or the RateLimit-* headers of the IETF draft.
+// Reset is accepted either as a Unix timestamp or as a number of seconds from now.
+func parseRateLimit(header http.Header) RateLimit {
+	var rateLimit RateLimit
+
+	if limit, err := strconv.Atoi(firstHeader(header, "X-RateLimit-Limit", "RateLimit-Limit")); err == nil {
+		rateLimit.Limit = limit
+	}
+
+	if remaining, err := strconv.Atoi(firstHeader(header, "X-RateLimit-Remaining", "RateLimit-Remaining")); err == nil {
+		rateLimit.Remaining = remaining
+	}
+
+	if reset, err := strconv.ParseInt(firstHeader(header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+		// Values this large can only be Unix timestamps.
+		if reset > 1000000000 {
+			rateLimit.Reset = time.Unix(reset, 0)
+		} else {
+			rateLimit.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	return rateLimit
+}
+
+func firstHeader(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
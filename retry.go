@@ -0,0 +1,200 @@
+package mailinator
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Only requests whose method is listed in Methods are retried, unless the call
+// context was marked with AllowRetry. Fetches with Delete set are only retried with AllowRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, including delays requested by Retry-After.
+	MaxBackoff time.Duration
+	// StatusCodes lists the response status codes that are retried.
+	StatusCodes []int
+	// Methods lists the HTTP methods that are retried without an explicit opt-in.
+	Methods []string
+}
+
+// DefaultRetryPolicy returns a policy retrying idempotent GETs up to 3 times on 429 and 5xx responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		Methods: []string{"GET", "HEAD"},
+	}
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry returns a context that lets the retry policy retry non-idempotent
+// calls such as PostMessage, CreateRule, or FetchInbox with Delete set.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+
+	if allowed, _ := req.Context().Value(allowRetryKey{}).(bool); allowed {
+		return true
+	}
+
+	// Fetches deleting the messages they return are not idempotent.
+	if req.URL.Query().Get("delete") != "" {
+		return false
+	}
+
+	for _, method := range p.Methods {
+		if method == req.Method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	for _, code := range p.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+// Half of the delay is randomized so that parallel clients do not retry in lockstep.
+func (p *RetryPolicy) backoff(retry int, res *http.Response) time.Duration {
+	if res != nil {
+		if delay, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				delay = p.MaxBackoff
+			}
+			return delay
+		}
+	}
+
+	delay := p.MinBackoff
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			delay = p.MaxBackoff
+			break
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// do sends req, retrying it according to the client's RetryPolicy.
+// It also returns the number of retries made.
+func (c *Client) do(req *http.Request) (*http.Response, int, error) {
+	policy := c.RetryPolicy
+	if !policy.allows(req) {
+		generation, err := c.admit(req)
+		if err != nil {
+			return nil, 0, err
+		}
+		res, err := c.attempt(req, generation)
+		return res, 0, err
+	}
+
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		retries := attempt - 1
+
+		generation, err := c.admit(req)
+		if err != nil {
+			return nil, retries, err
+		}
+
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, retries, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		res, err := c.attempt(attemptReq, generation)
+
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return res, retries, err
+		}
+
+		if err == nil && !policy.retryableStatus(res.StatusCode) {
+			return res, retries, nil
+		}
+
+		delay := policy.backoff(attempt, res)
+
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, retries, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package mailinator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyServer fails the first failures requests with status, and answers the next ones with body.
+func newFlakyServer(hits *int32, failures int32, status int, header http.Header, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(hits, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = 20 * time.Millisecond
+	policy.MaxBackoff = time.Second
+
+	return policy
+}
+
+func TestRetryPolicyRetriesWithBackoff(t *testing.T) {
+	var hits int32
+	server := newFlakyServer(&hits, 2, http.StatusServiceUnavailable, nil, `{"domains":[]}`)
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()), WithMetrics(metrics))
+
+	start := time.Now()
+	_, err := c.GetDomains()
+	elapsed := time.Since(start)
+
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits), "expecting two retries")
+	// The delays are at least half of 20ms, then of 40ms.
+	assert.True(t, elapsed >= 30*time.Millisecond, "expecting backoff between attempts, got %v", elapsed)
+
+	var out bytes.Buffer
+	metrics.WritePrometheus(&out)
+	assert.Contains(t, out.String(), `mailinator_retries_total{endpoint="GetDomains"} 2`, "expecting retries counted")
+}
+
+func TestRetryPolicyGivesUpAfterMaxAttempts(t *testing.T) {
+	var hits int32
+	server := newFlakyServer(&hits, 10, http.StatusBadGateway, nil, `{"domains":[]}`)
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	_, err := c.GetDomains()
+	assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits), "expecting MaxAttempts attempts")
+}
+
+func TestRetryPolicyCapsRetryAfter(t *testing.T) {
+	var hits int32
+	header := http.Header{"Retry-After": []string{"30"}}
+	server := newFlakyServer(&hits, 1, http.StatusTooManyRequests, header, `{"domains":[]}`)
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxBackoff = 50 * time.Millisecond
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	start := time.Now()
+	_, err := c.GetDomains()
+	elapsed := time.Since(start)
+
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits), "expecting one retry")
+	assert.True(t, elapsed >= 50*time.Millisecond, "expecting Retry-After honored up to MaxBackoff, got %v", elapsed)
+	assert.True(t, elapsed < time.Second, "expecting Retry-After capped by MaxBackoff, got %v", elapsed)
+}
+
+func TestRetryPolicySkipsNonIdempotentCalls(t *testing.T) {
+	var hits int32
+	server := newFlakyServer(&hits, 1, http.StatusServiceUnavailable, nil, `{"status":"ok","msgs":[]}`)
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	_, err := c.PostMessage(&PostMessageOptions{Domain: "d", Inbox: "i"})
+	assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "expecting POST not retried")
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = c.FetchInbox(&FetchInboxOptions{Domain: "d", Inbox: "i", Delete: "1s"})
+	assert.True(t, errors.Is(err, ErrServer), "expecting server error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "expecting deleting fetch not retried")
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = c.FetchInboxWithContext(AllowRetry(context.Background()), &FetchInboxOptions{Domain: "d", Inbox: "i", Delete: "1s"})
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits), "expecting deleting fetch retried with AllowRetry")
+}
@@ -2,8 +2,8 @@ package mailinator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -65,15 +65,24 @@ type Rules struct {
 
 // Creates a Rule. Note that in the examples, ":domain_id" can be one of your private domains.
 func (c *Client) CreateRule(options *CreateRuleOptions) (*Rule, error) {
+	return c.CreateRuleWithContext(context.Background(), options)
+}
+
+// CreateRuleWithContext is like CreateRule but carries the given context on the request.
+func (c *Client) CreateRuleWithContext(ctx context.Context, options *CreateRuleOptions) (*Rule, error) {
+	if err := c.guardDomainID(ctx, "CreateRule", options.DomainId); err != nil {
+		return nil, err
+	}
+
 	jsonReq, _ := json.Marshal(options.RuleToCreate)
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/%s/rules", c.baseURL, options.DomainId), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url("domains", options.DomainId, "rules"), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return nil, err
 	}
 
 	res := Rule{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("CreateRule", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -82,15 +91,24 @@ func (c *Client) CreateRule(options *CreateRuleOptions) (*Rule, error) {
 
 // Enable an existing Rule
 func (c *Client) EnableRule(options *EnableRuleOptions) (*ResponseStatus, error) {
+	return c.EnableRuleWithContext(context.Background(), options)
+}
+
+// EnableRuleWithContext is like EnableRule but carries the given context on the request.
+func (c *Client) EnableRuleWithContext(ctx context.Context, options *EnableRuleOptions) (*ResponseStatus, error) {
+	if err := c.guardDomainID(ctx, "EnableRule", options.DomainId); err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/domains/%s/rules/%s/enable", c.baseURL, options.DomainId, options.RuleId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.url("domains", options.DomainId, "rules", options.RuleId, "enable"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatus{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("EnableRule", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -99,15 +117,24 @@ func (c *Client) EnableRule(options *EnableRuleOptions) (*ResponseStatus, error)
 
 // Disable an existing Rule
 func (c *Client) DisableRule(options *DisableRuleOptions) (*ResponseStatus, error) {
+	return c.DisableRuleWithContext(context.Background(), options)
+}
+
+// DisableRuleWithContext is like DisableRule but carries the given context on the request.
+func (c *Client) DisableRuleWithContext(ctx context.Context, options *DisableRuleOptions) (*ResponseStatus, error) {
+	if err := c.guardDomainID(ctx, "DisableRule", options.DomainId); err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/domains/%s/rules/%s/disable", c.baseURL, options.DomainId, options.RuleId), &buf)
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.url("domains", options.DomainId, "rules", options.RuleId, "disable"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatus{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DisableRule", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -116,13 +143,18 @@ func (c *Client) DisableRule(options *DisableRuleOptions) (*ResponseStatus, erro
 
 // Fetches a All Rules for a Domain
 func (c *Client) GetAllRules(options *GetAllRulesOptions) (*Rules, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/rules", c.baseURL, options.DomainId), nil)
+	return c.GetAllRulesWithContext(context.Background(), options)
+}
+
+// GetAllRulesWithContext is like GetAllRules but carries the given context on the request.
+func (c *Client) GetAllRulesWithContext(ctx context.Context, options *GetAllRulesOptions) (*Rules, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.DomainId, "rules"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Rules{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetAllRules", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -131,13 +163,18 @@ func (c *Client) GetAllRules(options *GetAllRulesOptions) (*Rules, error) {
 
 // Fetches a Rules for a Domain
 func (c *Client) GetRule(options *GetRuleOptions) (*Rule, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/%s/rules/%s", c.baseURL, options.DomainId, options.RuleId), nil)
+	return c.GetRuleWithContext(context.Background(), options)
+}
+
+// GetRuleWithContext is like GetRule but carries the given context on the request.
+func (c *Client) GetRuleWithContext(ctx context.Context, options *GetRuleOptions) (*Rule, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.DomainId, "rules", options.RuleId), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := Rule{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetRule", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -146,13 +183,30 @@ func (c *Client) GetRule(options *GetRuleOptions) (*Rule, error) {
 
 // Deletes a specific Rule from a Domain
 func (c *Client) DeleteRule(options *DeleteRuleOptions) (*ResponseStatus, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/domains/%s/rules/%s", c.baseURL, options.DomainId, options.RuleId), nil)
+	return c.DeleteRuleWithContext(context.Background(), options)
+}
+
+// DeleteRuleWithContext is like DeleteRule but carries the given context on the request.
+func (c *Client) DeleteRuleWithContext(ctx context.Context, options *DeleteRuleOptions) (*ResponseStatus, error) {
+	if err := c.guardDomainID(ctx, "DeleteRule", options.DomainId); err != nil {
+		return nil, err
+	}
+
+	if c.isDryRun(ctx) {
+		if _, err := c.previewRules(ctx, "DeleteRule", options.DomainId, options.RuleId); err != nil {
+			return nil, err
+		}
+
+		return &ResponseStatus{Status: DryRunStatus}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url("domains", options.DomainId, "rules", options.RuleId), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatus{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("DeleteRule", req, &res); err != nil {
 		return nil, err
 	}
 
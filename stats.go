@@ -1,7 +1,7 @@
 package mailinator
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -80,13 +80,18 @@ type PlanData struct {
 
 // Retrieves stats of team
 func (c *Client) GetTeamStats() (*TeamStats, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/team/stats", c.baseURL), nil)
+	return c.GetTeamStatsWithContext(context.Background())
+}
+
+// GetTeamStatsWithContext is like GetTeamStats but carries the given context on the request.
+func (c *Client) GetTeamStatsWithContext(ctx context.Context) (*TeamStats, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("team", "stats"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := TeamStats{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetTeamStats", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -95,13 +100,18 @@ func (c *Client) GetTeamStats() (*TeamStats, error) {
 
 // Retrieves team info
 func (c *Client) GetTeam() (*TeamInfo, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/team/", c.baseURL), nil)
+	return c.GetTeamWithContext(context.Background())
+}
+
+// GetTeamWithContext is like GetTeam but carries the given context on the request.
+func (c *Client) GetTeamWithContext(ctx context.Context) (*TeamInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("team")+"/", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := TeamInfo{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetTeam", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -110,13 +120,18 @@ func (c *Client) GetTeam() (*TeamInfo, error) {
 
 // Retrieves team info
 func (c *Client) GetTeamInfo() (*TeamInfoData, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/teaminfo", c.baseURL), nil)
+	return c.GetTeamInfoWithContext(context.Background())
+}
+
+// GetTeamInfoWithContext is like GetTeamInfo but carries the given context on the request.
+func (c *Client) GetTeamInfoWithContext(ctx context.Context) (*TeamInfoData, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("teaminfo"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	res := TeamInfoData{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("GetTeamInfo", req, &res); err != nil {
 		return nil, err
 	}
 
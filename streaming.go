@@ -0,0 +1,182 @@
+package mailinator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// Download is a streamed response body along with its metadata. It must be closed.
+type Download struct {
+	io.ReadCloser
+	// FileName is the file name from the Content-Disposition header, if any.
+	FileName string
+	// ContentType is the media type of the body.
+	ContentType string
+	// Size is the length of the body in bytes, or -1 if unknown.
+	Size int64
+}
+
+func newDownload(res *http.Response) *Download {
+	download := &Download{
+		ReadCloser:  res.Body,
+		ContentType: res.Header.Get("Content-Type"),
+		Size:        res.ContentLength,
+	}
+
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		download.FileName = params["filename"]
+	}
+
+	return download
+}
+
+func (c *Client) stream(endpoint string, req *http.Request) (*Download, error) {
+	res, err := c.sendStreamRequest(endpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDownload(res), nil
+}
+
+// Streams a specific attachment for specific inbox, without loading it into memory.
+func (c *Client) StreamInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions) (*Download, error) {
+	return c.StreamInboxMessageAttachmentWithContext(context.Background(), options)
+}
+
+// StreamInboxMessageAttachmentWithContext is like StreamInboxMessageAttachment but carries the given context on the request.
+func (c *Client) StreamInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions) (*Download, error) {
+	var buf bytes.Buffer
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "attachments", strconv.Itoa(options.AttachmentId)), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stream("StreamInboxMessageAttachment", req)
+}
+
+// Streams a specific attachment, without loading it into memory.
+func (c *Client) StreamMessageAttachment(options *FetchMessageAttachmentOptions) (*Download, error) {
+	return c.StreamMessageAttachmentWithContext(context.Background(), options)
+}
+
+// StreamMessageAttachmentWithContext is like StreamMessageAttachment but carries the given context on the request.
+func (c *Client) StreamMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions) (*Download, error) {
+	var buf bytes.Buffer
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "attachments", strconv.Itoa(options.AttachmentId)), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stream("StreamMessageAttachment", req)
+}
+
+// Streams the raw MIME source of the email, without loading it into memory.
+func (c *Client) StreamMessageRaw(options *FetchMessageRawOptions) (*Download, error) {
+	return c.StreamMessageRawWithContext(context.Background(), options)
+}
+
+// StreamMessageRawWithContext is like StreamMessageRaw but carries the given context on the request.
+func (c *Client) StreamMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions) (*Download, error) {
+	var buf bytes.Buffer
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "messages", options.MessageId, "raw"), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stream("StreamMessageRaw", req)
+}
+
+// Streams the raw MIME source of the email for specific inbox, without loading it into memory.
+func (c *Client) StreamInboxMessageRaw(options *FetchInboxMessageRawOptions) (*Download, error) {
+	return c.StreamInboxMessageRawWithContext(context.Background(), options)
+}
+
+// StreamInboxMessageRawWithContext is like StreamInboxMessageRaw but carries the given context on the request.
+func (c *Client) StreamInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions) (*Download, error) {
+	var buf bytes.Buffer
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url("domains", options.Domain, "inboxes", options.Inbox, "messages", options.MessageId, "raw"), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stream("StreamInboxMessageRaw", req)
+}
+
+// Writes a specific attachment to w and returns its metadata.
+func (c *Client) DownloadMessageAttachment(options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	return c.DownloadMessageAttachmentWithContext(context.Background(), options, w)
+}
+
+// DownloadMessageAttachmentWithContext is like DownloadMessageAttachment but carries the given context on the request.
+func (c *Client) DownloadMessageAttachmentWithContext(ctx context.Context, options *FetchMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	download, err := c.StreamMessageAttachmentWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return download, copyDownload(w, download)
+}
+
+// Writes a specific attachment for specific inbox to w and returns its metadata.
+func (c *Client) DownloadInboxMessageAttachment(options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	return c.DownloadInboxMessageAttachmentWithContext(context.Background(), options, w)
+}
+
+// DownloadInboxMessageAttachmentWithContext is like DownloadInboxMessageAttachment but carries the given context on the request.
+func (c *Client) DownloadInboxMessageAttachmentWithContext(ctx context.Context, options *FetchInboxMessageAttachmentOptions, w io.Writer) (*Download, error) {
+	download, err := c.StreamInboxMessageAttachmentWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return download, copyDownload(w, download)
+}
+
+// Writes the raw MIME source of the email to w and returns its metadata.
+func (c *Client) DownloadMessageRaw(options *FetchMessageRawOptions, w io.Writer) (*Download, error) {
+	return c.DownloadMessageRawWithContext(context.Background(), options, w)
+}
+
+// DownloadMessageRawWithContext is like DownloadMessageRaw but carries the given context on the request.
+func (c *Client) DownloadMessageRawWithContext(ctx context.Context, options *FetchMessageRawOptions, w io.Writer) (*Download, error) {
+	download, err := c.StreamMessageRawWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return download, copyDownload(w, download)
+}
+
+// Writes the raw MIME source of the email for specific inbox to w and returns its metadata.
+func (c *Client) DownloadInboxMessageRaw(options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error) {
+	return c.DownloadInboxMessageRawWithContext(context.Background(), options, w)
+}
+
+// DownloadInboxMessageRawWithContext is like DownloadInboxMessageRaw but carries the given context on the request.
+func (c *Client) DownloadInboxMessageRawWithContext(ctx context.Context, options *FetchInboxMessageRawOptions, w io.Writer) (*Download, error) {
+	download, err := c.StreamInboxMessageRawWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return download, copyDownload(w, download)
+}
+
+// copyDownload writes the body of download to w, closes it and records the number of bytes written as its Size.
+func copyDownload(w io.Writer, download *Download) error {
+	defer download.Close()
+
+	n, err := io.Copy(w, download)
+	download.Size = n
+
+	return err
+}
@@ -0,0 +1,120 @@
+package mailinator
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// SpanInfo describes the Client call a span is started for.
+type SpanInfo struct {
+	// Endpoint is the name of the Client method, e.g. "FetchInbox".
+	Endpoint string
+	// Method is the HTTP method.
+	Method string
+	// Domain is the domain the call targets, if any.
+	Domain string
+	// Inbox is the inbox the call targets, if any.
+	Inbox string
+}
+
+// SpanContext identifies a span in a W3C trace context.
+type SpanContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Sampled    bool
+	TraceState string
+}
+
+// IsValid reports whether both the trace and span IDs are set.
+func (s SpanContext) IsValid() bool {
+	return s.TraceID != [16]byte{} && s.SpanID != [8]byte{}
+}
+
+// TraceParent returns the W3C traceparent header value of the span.
+func (s SpanContext) TraceParent() string {
+	flags := "00"
+	if s.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + hex.EncodeToString(s.TraceID[:]) + "-" + hex.EncodeToString(s.SpanID[:]) + "-" + flags
+}
+
+// Span is a span opened by a Tracer for a Client call.
+type Span interface {
+	// SpanContext returns the IDs propagated to the API in the traceparent header.
+	// An invalid SpanContext propagates nothing.
+	SpanContext() SpanContext
+	// End ends the span with the status code of the last response, or 0 if none was received,
+	// the number of retries made and the error returned by the call.
+	End(statusCode int, retries int, err error)
+}
+
+// Tracer opens a span for every Client call. It must be safe for concurrent use.
+// Start returns the context the call continues with, which usually carries the new span.
+type Tracer interface {
+	Start(ctx context.Context, info SpanInfo) (context.Context, Span)
+}
+
+// WithTracer makes the client open a span with tracer for every call and send its
+// trace context to the API in the W3C traceparent and tracestate headers.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+type spanKey struct{}
+
+// startSpan opens the span of a call and adds its trace context to req.
+func (c *Client) startSpan(endpoint string, req *http.Request) *http.Request {
+	if c.tracer == nil {
+		return req
+	}
+
+	domain, inbox := targetOf(req)
+	ctx, span := c.tracer.Start(req.Context(), SpanInfo{
+		Endpoint: endpoint,
+		Method:   req.Method,
+		Domain:   domain,
+		Inbox:    inbox,
+	})
+	req = req.WithContext(context.WithValue(ctx, spanKey{}, span))
+
+	if sc := span.SpanContext(); sc.IsValid() {
+		req.Header.Set("traceparent", sc.TraceParent())
+		if sc.TraceState != "" {
+			req.Header.Set("tracestate", sc.TraceState)
+		}
+	}
+
+	return req
+}
+
+// endSpan ends the span started for req, if any.
+func endSpan(req *http.Request, statusCode int, retries int, err error) {
+	if span, ok := req.Context().Value(spanKey{}).(Span); ok {
+		span.End(statusCode, retries, err)
+	}
+}
+
+// targetOf returns the domain and inbox in the path of req.
+func targetOf(req *http.Request) (domain string, inbox string) {
+	segments := strings.Split(req.URL.EscapedPath(), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		switch segments[i] {
+		case "domains":
+			if domain == "" {
+				domain = unescapeSegment(segments[i+1])
+			}
+		case "inboxes":
+			if inbox == "" {
+				inbox = unescapeSegment(segments[i+1])
+			}
+		}
+	}
+
+	return domain, inbox
+}
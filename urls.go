@@ -0,0 +1,62 @@
+package mailinator
+
+import (
+	"net/url"
+	"strings"
+)
+
+// url returns the URL of the API resource made of segments, such as "domains", domain, "inboxes", inbox.
+// Each segment is escaped, so values may contain any character. The API's special forms are kept:
+// "*" for the latest messages or wildcard inboxes, and comma-separated inbox lists.
+func (c *Client) url(segments ...string) string {
+	var b strings.Builder
+	b.WriteString(c.baseURL)
+
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(escapeSegment(segment))
+	}
+
+	return b.String()
+}
+
+// addQuery returns u with the query parameter key set to the escaped value.
+func addQuery(u string, key string, value string) string {
+	separator := "?"
+	if strings.Contains(u, "?") {
+		separator = "&"
+	}
+
+	return u + separator + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
+
+// escapeSegment escapes a path segment, keeping the characters with a meaning for the API.
+// The dot segments "." and ".." are escaped too, so that servers normalizing paths do not
+// resolve them to the parent resource.
+func escapeSegment(segment string) string {
+	if segment == "." || segment == ".." {
+		return strings.Repeat("%2E", len(segment))
+	}
+
+	parts := strings.Split(segment, ",")
+	for i, part := range parts {
+		wildcards := strings.Split(part, "*")
+		for j, wildcard := range wildcards {
+			// PathEscape leaves '+' alone, which some servers decode as a space.
+			wildcards[j] = strings.Replace(url.PathEscape(wildcard), "+", "%2B", -1)
+		}
+		parts[i] = strings.Join(wildcards, "*")
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// unescapeSegment reverses escapeSegment, returning segment as is if it is not validly escaped.
+func unescapeSegment(segment string) string {
+	unescaped, err := url.PathUnescape(segment)
+	if err != nil {
+		return segment
+	}
+
+	return unescaped
+}
@@ -0,0 +1,36 @@
+package mailinator
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeSegment(t *testing.T) {
+	tests := map[string]string{
+		"inbox":       "inbox",
+		"a b/c":       "a%20b%2Fc",
+		"a+b":         "a%2Bb",
+		"test-*":      "test-*",
+		"one,two":     "one,two",
+		".":           "%2E",
+		"..":          "%2E%2E",
+		"...":         "...",
+		"..,inbox":    "..,inbox",
+		"inbox.name.": "inbox.name.",
+	}
+
+	for segment, want := range tests {
+		assert.Equal(t, want, escapeSegment(segment), "escaping %q", segment)
+	}
+}
+
+func TestURLDotSegments(t *testing.T) {
+	c := NewClient("token", WithBaseURL("https://api.example.com/api/v2"))
+
+	req, err := http.NewRequest("DELETE", c.url("domains", "d", "inboxes", ".."), nil)
+	assert.Nil(t, err, "expecting nil error")
+	assert.Equal(t, "/api/v2/domains/d/inboxes/%2E%2E", req.URL.EscapedPath(), "expecting escaped dot segment")
+	assert.Equal(t, "..", unescapeSegment("%2E%2E"), "expecting unescaped dot segment")
+}
@@ -0,0 +1,165 @@
+package mailinator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CustomServiceResponse is the response to a custom service webhook.
+type CustomServiceResponse struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	// Body is the raw response body, which custom services may answer in their own format.
+	Body string `json:"-"`
+}
+
+// WebhookClient injects messages into the private domain of a team with a webhook token.
+// Unlike Client, it needs no API token, so it can be handed to systems that must not hold one.
+type WebhookClient struct {
+	client *Client
+	token  string
+
+	// TokenInHeader sends the webhook token in the Authorization header instead of the whtoken
+	// query parameter, keeping it out of URLs logged by proxies. The query parameter is the form
+	// documented by the API; only enable this where the API or a gateway in front of it accepts the header.
+	TokenInHeader bool
+}
+
+// NewWebhookClient creates a WebhookClient with the given webhook token, configured by the given
+// options. Options setting credentials are ignored, so no API token is ever sent.
+func NewWebhookClient(webhookToken string, options ...Option) *WebhookClient {
+	client := NewClient("", options...)
+	client.credentials = nil
+
+	return &WebhookClient{client: client, token: webhookToken}
+}
+
+// PrivateWebhook delivers a message to the private domain inbox designated by the "to" field of the webhook.
+// options.WebhookToken is ignored in favor of the client's token.
+func (w *WebhookClient) PrivateWebhook(options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	return w.PrivateWebhookWithContext(context.Background(), options)
+}
+
+// PrivateWebhookWithContext is like PrivateWebhook but carries the given context on the request.
+func (w *WebhookClient) PrivateWebhookWithContext(ctx context.Context, options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	if err := w.client.guard("PrivateWebhook", "private", webhookInbox("", options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
+	req, err := w.newRequest(ctx, options.Webhook, "domains", "private", "webhook")
+	if err != nil {
+		return nil, err
+	}
+
+	res := ResponseStatusWithId{}
+	if err := w.client.sendRequest("PrivateWebhook", req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PrivateInboxWebhook delivers a message to the given inbox of the private domain.
+// options.WebhookToken is ignored in favor of the client's token.
+func (w *WebhookClient) PrivateInboxWebhook(options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	return w.PrivateInboxWebhookWithContext(context.Background(), options)
+}
+
+// PrivateInboxWebhookWithContext is like PrivateInboxWebhook but carries the given context on the request.
+func (w *WebhookClient) PrivateInboxWebhookWithContext(ctx context.Context, options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	if err := w.client.guard("PrivateInboxWebhook", "private", webhookInbox(options.Inbox, options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
+	req, err := w.newRequest(ctx, options.Webhook, "domains", "private", "webhook", options.Inbox)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ResponseStatusWithId{}
+	if err := w.client.sendRequest("PrivateInboxWebhook", req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PrivateCustomServiceWebhook delivers a message published by a custom service, such as Twilio,
+// mapping its fields to the Mailinator system. options.WebhookToken is ignored in favor of the client's token.
+func (w *WebhookClient) PrivateCustomServiceWebhook(options *PrivateCustomServiceWebhookOptions) (*CustomServiceResponse, error) {
+	return w.PrivateCustomServiceWebhookWithContext(context.Background(), options)
+}
+
+// PrivateCustomServiceWebhookWithContext is like PrivateCustomServiceWebhook but carries the given context on the request.
+func (w *WebhookClient) PrivateCustomServiceWebhookWithContext(ctx context.Context, options *PrivateCustomServiceWebhookOptions) (*CustomServiceResponse, error) {
+	if err := w.client.guard("PrivateCustomServiceWebhook", "private", webhookInbox("", options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
+	req, err := w.newRequest(ctx, options.Webhook, "domains", "private", options.CustomService)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.sendCustomService("PrivateCustomServiceWebhook", req)
+}
+
+// PrivateCustomServiceInboxWebhook is like PrivateCustomServiceWebhook but delivers the message to the given inbox.
+func (w *WebhookClient) PrivateCustomServiceInboxWebhook(options *PrivateCustomServiceInboxWebhookOptions) (*CustomServiceResponse, error) {
+	return w.PrivateCustomServiceInboxWebhookWithContext(context.Background(), options)
+}
+
+// PrivateCustomServiceInboxWebhookWithContext is like PrivateCustomServiceInboxWebhook but carries the given context on the request.
+func (w *WebhookClient) PrivateCustomServiceInboxWebhookWithContext(ctx context.Context, options *PrivateCustomServiceInboxWebhookOptions) (*CustomServiceResponse, error) {
+	if err := w.client.guard("PrivateCustomServiceInboxWebhook", "private", webhookInbox(options.Inbox, options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
+	req, err := w.newRequest(ctx, options.Webhook, "domains", "private", options.CustomService, options.Inbox)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.sendCustomService("PrivateCustomServiceInboxWebhook", req)
+}
+
+// newRequest builds the POST of webhook to the resource made of segments, carrying the webhook token.
+func (w *WebhookClient) newRequest(ctx context.Context, webhook Webhook, segments ...string) (*http.Request, error) {
+	jsonReq, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	url := w.client.url(segments...)
+	if !w.TokenInHeader {
+		url = addQuery(url, "whtoken", w.token)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonReq))
+	if err != nil {
+		return nil, err
+	}
+
+	if w.TokenInHeader {
+		req.Header.Set("Authorization", w.token)
+	}
+
+	return req, nil
+}
+
+// sendCustomService sends req and decodes the response, keeping the raw body when it is not JSON.
+func (w *WebhookClient) sendCustomService(endpoint string, req *http.Request) (*CustomServiceResponse, error) {
+	body := new(string)
+	if err := w.client.sendRequestWithOptions(endpoint, req, body, true); err != nil {
+		return nil, err
+	}
+
+	res := CustomServiceResponse{}
+	// Not every service answers in JSON; the raw body is kept either way.
+	json.Unmarshal([]byte(*body), &res)
+	res.Body = *body
+
+	return &res, nil
+}
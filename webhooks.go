@@ -2,8 +2,8 @@ package mailinator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -55,18 +55,27 @@ type ResponseStatusWithId struct {
 // This is because a typical use case is to enter the Webhook URL into 3rd-party systems(i.e.Twilio, Zapier, IFTTT, etc) and you should never give out your API Token .
 // Check your Team Settings where you can create "Webhook Tokens" designed for this purpose .
 func (c *Client) PrivateWebhook(options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	return c.PrivateWebhookWithContext(context.Background(), options)
+}
+
+// PrivateWebhookWithContext is like PrivateWebhook but carries the given context on the request.
+func (c *Client) PrivateWebhookWithContext(ctx context.Context, options *PrivateWebhookOptions) (*ResponseStatusWithId, error) {
+	if err := c.guard("PrivateWebhook", "private", webhookInbox("", options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
 	jsonReq, err := json.Marshal(options.Webhook)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/private/webhook?whtoken=%s", c.baseURL, options.WebhookToken), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", addQuery(c.url("domains", "private", "webhook"), "whtoken", options.WebhookToken), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatusWithId{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("PrivateWebhook", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -79,18 +88,27 @@ func (c *Client) PrivateWebhook(options *PrivateWebhookOptions) (*ResponseStatus
 // If the incoming JSON payload does not contain a "from" or "subject", then dummy values will be inserted in these fields .
 // You may retrieve such messages via the Web Interface, the API, or the Rule System .
 func (c *Client) PrivateInboxWebhook(options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	return c.PrivateInboxWebhookWithContext(context.Background(), options)
+}
+
+// PrivateInboxWebhookWithContext is like PrivateInboxWebhook but carries the given context on the request.
+func (c *Client) PrivateInboxWebhookWithContext(ctx context.Context, options *PrivateInboxWebhookOptions) (*ResponseStatusWithId, error) {
+	if err := c.guard("PrivateInboxWebhook", "private", webhookInbox(options.Inbox, options.Webhook.To)); err != nil {
+		return nil, err
+	}
+
 	jsonReq, err := json.Marshal(options.Webhook)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/private/webhook/%s?whtoken=%s", c.baseURL, options.Inbox, options.WebhookToken), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", addQuery(c.url("domains", "private", "webhook", options.Inbox), "whtoken", options.WebhookToken), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return nil, err
 	}
 
 	res := ResponseStatusWithId{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendRequest("PrivateInboxWebhook", req, &res); err != nil {
 		return nil, err
 	}
 
@@ -101,19 +119,28 @@ func (c *Client) PrivateInboxWebhook(options *PrivateInboxWebhookOptions) (*Resp
 // Mailinator intends to apply specific mappings for certain services that commonly publish webhooks .
 // If you test incoming Messages to SMS numbers via Twilio, you may use this endpoint to correctly map "to", "from", and "subject" of those messages to the Mailinator system.By default, the destination inbox is the Twilio phone number .
 func (c *Client) PrivateCustomServiceWebhook(options *PrivateCustomServiceWebhookOptions) error {
+	return c.PrivateCustomServiceWebhookWithContext(context.Background(), options)
+}
+
+// PrivateCustomServiceWebhookWithContext is like PrivateCustomServiceWebhook but carries the given context on the request.
+func (c *Client) PrivateCustomServiceWebhookWithContext(ctx context.Context, options *PrivateCustomServiceWebhookOptions) error {
+	if err := c.guard("PrivateCustomServiceWebhook", "private", webhookInbox("", options.Webhook.To)); err != nil {
+		return err
+	}
+
 	jsonReq, err := json.Marshal(options.Webhook)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/private/%s?whtoken=%s", c.baseURL, options.CustomService, options.WebhookToken), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", addQuery(c.url("domains", "private", options.CustomService), "whtoken", options.WebhookToken), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return err
 	}
 
 	res := new(string)
 
-	if err := c.sendRequestWithOptions(req, res, true); err != nil {
+	if err := c.sendRequestWithOptions("PrivateCustomServiceWebhook", req, res, true); err != nil {
 		return err
 	}
 
@@ -123,19 +150,28 @@ func (c *Client) PrivateCustomServiceWebhook(options *PrivateCustomServiceWebhoo
 // The SMS message will arrive in the Private Mailinator inbox corresponding to the Twilio Phone Number. (only the digits, if a plus sign precedes the number it will be removed)
 // If you wish the message to arrive in a different inbox, you may append the destination inbox to the URL .
 func (c *Client) PrivateCustomServiceInboxWebhook(options *PrivateCustomServiceInboxWebhookOptions) error {
+	return c.PrivateCustomServiceInboxWebhookWithContext(context.Background(), options)
+}
+
+// PrivateCustomServiceInboxWebhookWithContext is like PrivateCustomServiceInboxWebhook but carries the given context on the request.
+func (c *Client) PrivateCustomServiceInboxWebhookWithContext(ctx context.Context, options *PrivateCustomServiceInboxWebhookOptions) error {
+	if err := c.guard("PrivateCustomServiceInboxWebhook", "private", webhookInbox(options.Inbox, options.Webhook.To)); err != nil {
+		return err
+	}
+
 	jsonReq, err := json.Marshal(options.Webhook)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/domains/private/%s/%s?whtoken=%s", c.baseURL, options.CustomService, options.Inbox, options.WebhookToken), bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, "POST", addQuery(c.url("domains", "private", options.CustomService, options.Inbox), "whtoken", options.WebhookToken), bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return err
 	}
 
 	res := new(string)
 
-	if err := c.sendRequestWithOptions(req, res, true); err != nil {
+	if err := c.sendRequestWithOptions("PrivateCustomServiceInboxWebhook", req, res, true); err != nil {
 		return err
 	}
 